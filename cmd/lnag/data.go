@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/creimer/lnag/internal/curate"
+)
+
+// The defaults are relative to the repository root.
+const (
+	defaultDataDir     = "internal/data"
+	defaultCleanRules  = "tools/data/clean.json"
+	defaultProperRules = "tools/data/proper_nouns.json"
+)
+
+// runData runs the curation commands that rewrite the built-in library.
+func runData(args []string) {
+	if len(args) == 0 {
+		usage()
+	}
+	cmd, args := args[0], args[1:]
+	dir, rules := defaultDataDir, ""
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--dir":
+			i++
+			if i >= len(args) {
+				usage()
+			}
+			dir = args[i]
+		case "--rules":
+			i++
+			if i >= len(args) {
+				usage()
+			}
+			rules = args[i]
+		default:
+			usage()
+		}
+	}
+
+	var err error
+	switch cmd {
+	case "clean":
+		if rules == "" {
+			rules = defaultCleanRules
+		}
+		err = runClean(dir, rules)
+	case "mark-proper":
+		if rules == "" {
+			rules = defaultProperRules
+		}
+		err = runMarkProper(dir, rules)
+	default:
+		usage()
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runClean(dir, path string) error {
+	rules, err := curate.LoadCleanRules(path)
+	if err != nil {
+		return err
+	}
+	reports, err := curate.Clean(dir, rules)
+	for i, r := range reports {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("Processing %s...\n", r.File)
+		fmt.Printf("  %d → %d items (%d removed)\n", r.Before, r.After, r.Before-r.After)
+		if len(r.Renamed) > 0 {
+			fmt.Printf("  Renamed %d items:\n", len(r.Renamed))
+			for _, names := range r.Renamed {
+				fmt.Printf("    %s → %s\n", names[0], names[1])
+			}
+		}
+		if len(r.Removed) > 0 {
+			fmt.Printf("  Removed %d items\n", len(r.Removed))
+		}
+		if len(r.Missing) > 0 {
+			fmt.Printf("\n  WARNING: %d items in remove list not found:\n", len(r.Missing))
+			printNames(r.Missing)
+		}
+	}
+	return err
+}
+
+func runMarkProper(dir, path string) error {
+	rules, err := curate.LoadProperRules(path)
+	if err != nil {
+		return err
+	}
+	reports, err := curate.MarkProper(dir, rules)
+	for i, r := range reports {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("Marking proper nouns in %s...\n", r.File)
+		if len(r.Missing) > 0 {
+			fmt.Printf("  WARNING: %d names in proper set not found in data:\n", len(r.Missing))
+			printNames(r.Missing)
+		}
+		fmt.Printf("  %d/%d items marked as proper nouns\n", r.Marked, r.Total)
+	}
+	return err
+}
+
+func printNames(names []string) {
+	for _, n := range names {
+		fmt.Printf("    - %s\n", n)
+	}
+}
@@ -3,24 +3,83 @@ package main
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
 
-	"github.com/creimer/lnag/internal/data"
-	"github.com/creimer/lnag/internal/formatter"
-	"github.com/creimer/lnag/internal/matcher"
-	"github.com/creimer/lnag/internal/units"
+	"github.com/creimer/lnag/lnag"
 )
 
 func usage() {
 	fmt.Fprintf(os.Stderr, "Usage:\n")
-	fmt.Fprintf(os.Stderr, "  lnag <number> --unit <unit>\n")
-	fmt.Fprintf(os.Stderr, "  lnag <number> --dimension <dimension>\n")
+	fmt.Fprintf(os.Stderr, "  lnag <quantity> [options]        e.g. lnag \"3.2 million km\"\n")
+	fmt.Fprintf(os.Stderr, "  lnag <number> --unit <unit> [options]\n")
+	fmt.Fprintf(os.Stderr, "  lnag <quantity> --time [options] e.g. lnag 60 km/s --time, lnag 1 MWh --time\n")
+	fmt.Fprintf(os.Stderr, "  lnag <number> --dimension <dimension> [options]\n")
+	fmt.Fprintf(os.Stderr, "  lnag <number> --item <name> [--dimension <dimension>] [options]\n")
+	fmt.Fprintf(os.Stderr, "  lnag serve [--addr <host:port>] [--locale <locale>] [--rates <file>] [--prices <file>] [--concepts <path>]\n")
+	fmt.Fprintf(os.Stderr, "  lnag validate [--strict] [<path>...]  check concept files, or the built-in library\n")
+	fmt.Fprintf(os.Stderr, "  lnag data <clean|mark-proper> [--rules <file>] [--dir <dir>]\n")
+	fmt.Fprintf(os.Stderr, "               apply curation rules to the built-in library's files\n")
+	fmt.Fprintf(os.Stderr, "\nOptions:\n")
+	fmt.Fprintf(os.Stderr, "  --seed <n>   seed the random source for reproducible output\n")
+	fmt.Fprintf(os.Stderr, "  --best       always pick the best-scoring analogy\n")
+	fmt.Fprintf(os.Stderr, "  --count <n>  print the n best distinct analogies\n")
+	fmt.Fprintf(os.Stderr, "  --all        print every analogy that could have been picked\n")
+	fmt.Fprintf(os.Stderr, "  --cite       list the source and notes of each concept used\n")
+	fmt.Fprintf(os.Stderr, "  --emphasize <small|large>\n")
+	fmt.Fprintf(os.Stderr, "               stress how small or how large the number is\n")
+	fmt.Fprintf(os.Stderr, "  --locale <en-US|en-GB>\n")
+	fmt.Fprintf(os.Stderr, "               what \"ton\", \"gallon\" and \"fl oz\" mean (default en-US)\n")
+	fmt.Fprintf(os.Stderr, "  --units <auto|metric|imperial>\n")
+	fmt.Fprintf(os.Stderr, "               restate the input in a readable unit of that system\n")
+	fmt.Fprintf(os.Stderr, "  --rates <file>\n")
+	fmt.Fprintf(os.Stderr, "               currency rates to US dollars, e.g. {\"usd_per_unit\": {\"EUR\": 1.09}}\n")
+	fmt.Fprintf(os.Stderr, "  --prices <file>\n")
+	fmt.Fprintf(os.Stderr, "               concept prices to use instead of the built-in ones\n")
+	fmt.Fprintf(os.Stderr, "  --concepts <path>\n")
+	fmt.Fprintf(os.Stderr, "               extra concepts from a JSON file or directory; repeatable, and\n")
+	fmt.Fprintf(os.Stderr, "               %s may list more, separated by %q\n", conceptsEnv, string(os.PathListSeparator))
 	os.Exit(1)
 }
 
+// conceptsEnv names the environment variable listing concept files and
+// directories to load before any given with --concepts.
+const conceptsEnv = "LNAG_CONCEPTS"
+
+// envOptions returns the options set by the environment.
+func envOptions() []lnag.Option {
+	var paths []string
+	for _, p := range filepath.SplitList(os.Getenv(conceptsEnv)) {
+		if p != "" {
+			paths = append(paths, p)
+		}
+	}
+	if len(paths) == 0 {
+		return nil
+	}
+	return []lnag.Option{lnag.WithConcepts(paths...)}
+}
+
 func main() {
-	var number string
-	var unitFlag, dimFlag string
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		runServe(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "validate" {
+		runValidate(os.Args[2:])
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == "data" {
+		runData(os.Args[2:])
+		return
+	}
+
+	var positional []string
+	var unitFlag, dimFlag, itemFlag string
+	opts := envOptions()
+	top := 1
+	timeMode := false
 
 	args := os.Args[1:]
 	for i := 0; i < len(args); i++ {
@@ -37,56 +96,180 @@ func main() {
 				usage()
 			}
 			dimFlag = args[i]
-		default:
-			if number != "" {
+		case "--item":
+			i++
+			if i >= len(args) {
 				usage()
 			}
-			number = args[i]
+			itemFlag = args[i]
+		case "--seed":
+			i++
+			if i >= len(args) {
+				usage()
+			}
+			seed, err := strconv.ParseUint(args[i], 10, 64)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %q is not a valid seed\n", args[i])
+				os.Exit(1)
+			}
+			opts = append(opts, lnag.WithSeed(seed))
+		case "--time":
+			timeMode = true
+		case "--best":
+			opts = append(opts, lnag.WithBestOnly())
+		case "--cite":
+			opts = append(opts, lnag.WithCitations())
+		case "--count":
+			i++
+			if i >= len(args) {
+				usage()
+			}
+			n, err := strconv.Atoi(args[i])
+			if err != nil || n < 1 {
+				fmt.Fprintf(os.Stderr, "Error: --count must be a positive integer, got %q\n", args[i])
+				os.Exit(1)
+			}
+			top = n
+		case "--all":
+			top = 0
+		case "--emphasize":
+			i++
+			if i >= len(args) {
+				usage()
+			}
+			emphasis, err := lnag.ParseEmphasis(args[i])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			opts = append(opts, lnag.WithEmphasis(emphasis))
+		case "--locale":
+			i++
+			if i >= len(args) {
+				usage()
+			}
+			locale, err := lnag.ParseLocale(args[i])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			opts = append(opts, lnag.WithLocale(locale))
+		case "--units":
+			i++
+			if i >= len(args) {
+				usage()
+			}
+			system, err := lnag.ParseUnitSystem(args[i])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			opts = append(opts, lnag.WithUnitSystem(system))
+		case "--rates":
+			i++
+			if i >= len(args) {
+				usage()
+			}
+			if err := lnag.LoadRatesFile(args[i]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		case "--prices":
+			i++
+			if i >= len(args) {
+				usage()
+			}
+			opts = append(opts, lnag.WithPrices(args[i]))
+		case "--concepts":
+			i++
+			if i >= len(args) {
+				usage()
+			}
+			opts = append(opts, lnag.WithConcepts(args[i]))
+		default:
+			positional = append(positional, args[i])
 		}
 	}
 
-	if number == "" {
+	if len(positional) == 0 {
 		usage()
 	}
 
-	value, err := strconv.ParseFloat(number, 64)
+	// The quantity may be one argument ("3.2 million km") or several.
+	q, err := lnag.ParseQuantity(strings.Join(positional, " "))
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %q is not a valid number\n", number)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
+	value := q.Value
+	if q.Unit != "" {
+		if unitFlag != "" {
+			fmt.Fprintf(os.Stderr, "Error: unit given twice (%q and --unit %q)\n", q.Unit, unitFlag)
+			os.Exit(1)
+		}
+		unitFlag = q.Unit
+	}
 
-	if (unitFlag == "") == (dimFlag == "") {
-		fmt.Fprintf(os.Stderr, "Error: exactly one of --unit or --dimension must be provided\n")
+	if unitFlag != "" && dimFlag != "" {
+		fmt.Fprintf(os.Stderr, "Error: at most one of --unit or --dimension may be provided\n")
+		os.Exit(1)
+	}
+	if timeMode && (unitFlag == "" || itemFlag != "") {
+		fmt.Fprintf(os.Stderr, "Error: --time needs a speed, power or energy unit and cannot be combined with --item\n")
+		os.Exit(1)
+	}
+	if unitFlag != "" && itemFlag != "" {
+		fmt.Fprintf(os.Stderr, "Error: --unit cannot be combined with --item\n")
 		os.Exit(1)
 	}
 
-	store, err := data.NewConceptStore()
+	gen, err := lnag.New(opts...)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error loading concepts: %v\n", err)
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 		os.Exit(1)
 	}
 
-	if unitFlag != "" {
-		baseValue, dimension, err := units.Convert(value, unitFlag)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
+	var results []lnag.Result
+	switch {
+	case top == 1 && timeMode:
+		var res lnag.Result
+		res, err = gen.AnalogizeTime(value, unitFlag)
+		results = []lnag.Result{res}
+	case timeMode:
+		results, err = gen.AnalogizeTimeTop(value, unitFlag, top)
+	case top == 1 && unitFlag != "":
+		var res lnag.Result
+		res, err = gen.Analogize(value, unitFlag)
+		results = []lnag.Result{res}
+	case top == 1 && itemFlag != "":
+		var res lnag.Result
+		res, err = gen.AnalogizeItem(value, itemFlag, dimFlag)
+		results = []lnag.Result{res}
+	case top == 1:
+		var res lnag.Result
+		res, err = gen.AnalogizeCount(value, dimFlag)
+		results = []lnag.Result{res}
+	case unitFlag != "":
+		results, err = gen.AnalogizeTop(value, unitFlag, top)
+	case itemFlag != "":
+		results, err = gen.AnalogizeItemTop(value, itemFlag, dimFlag, top)
+	default:
+		results, err = gen.AnalogizeCountTop(value, dimFlag, top)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
 
-		result, err := matcher.FindUnitMatch(baseValue, dimension, store)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+	for _, res := range results {
+		if unitFlag == "" && dimFlag == "" && itemFlag == "" {
+			// The dimension was picked for the caller; say which one.
+			fmt.Printf("[%s] %s\n", res.Dimension, res.Sentence)
+		} else {
+			fmt.Println(res.Sentence)
 		}
-
-		fmt.Println(formatter.FormatUnitResult(result, value, unitFlag))
-	} else {
-		result, err := matcher.FindDimensionMatch(value, dimFlag, store)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+		for _, c := range res.Citations {
+			fmt.Printf("  - %s\n", c)
 		}
-
-		fmt.Println(formatter.FormatDimensionResult(result))
 	}
 }
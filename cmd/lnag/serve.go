@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+
+	"github.com/creimer/lnag/internal/server"
+	"github.com/creimer/lnag/lnag"
+)
+
+const defaultAddr = ":8080"
+
+func runServe(args []string) {
+	addr := defaultAddr
+	opts := envOptions()
+	for i := 0; i < len(args); i++ {
+		switch args[i] {
+		case "--addr":
+			i++
+			if i >= len(args) {
+				usage()
+			}
+			addr = args[i]
+		case "--locale":
+			i++
+			if i >= len(args) {
+				usage()
+			}
+			locale, err := lnag.ParseLocale(args[i])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			opts = append(opts, lnag.WithLocale(locale))
+		case "--rates":
+			i++
+			if i >= len(args) {
+				usage()
+			}
+			if err := lnag.LoadRatesFile(args[i]); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		case "--prices":
+			i++
+			if i >= len(args) {
+				usage()
+			}
+			opts = append(opts, lnag.WithPrices(args[i]))
+		case "--concepts":
+			i++
+			if i >= len(args) {
+				usage()
+			}
+			opts = append(opts, lnag.WithConcepts(args[i]))
+		default:
+			usage()
+		}
+	}
+
+	gen, err := lnag.New(opts...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	log.Printf("lnag listening on %s", addr)
+	if err := http.ListenAndServe(addr, server.New(gen)); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
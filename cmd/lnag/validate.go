@@ -0,0 +1,52 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/creimer/lnag/internal/data"
+)
+
+// runValidate checks concept files, or the built-in library when none are
+// given, and exits non-zero if any has problems.
+func runValidate(args []string) {
+	var paths []string
+	strict := false
+	for _, arg := range args {
+		switch arg {
+		case "--strict":
+			strict = true
+		default:
+			if len(arg) > 1 && arg[0] == '-' {
+				usage()
+			}
+			paths = append(paths, arg)
+		}
+	}
+
+	var problems []data.Problem
+	var err error
+	if len(paths) == 0 {
+		problems, err = data.ValidateLibrary()
+	} else {
+		problems, err = data.ValidateFiles(paths...)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	hints := 0
+	for _, p := range problems {
+		fmt.Println(p)
+		if p.Hint {
+			hints++
+		}
+	}
+	if len(problems) > 0 {
+		fmt.Fprintf(os.Stderr, "%d problems, %d of them hints\n", len(problems), hints)
+	}
+	if data.Failed(problems) || strict && len(problems) > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,190 @@
+package curate
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// CleanRule says which concepts to remove from, and rename in, one file of
+// the library. Removal is by the name in the file before any renaming.
+type CleanRule struct {
+	File   string              `json:"file"`
+	Remove map[string][]string `json:"remove"` // names, grouped by why they go
+	Rename map[string]string   `json:"rename"` // old name to new name
+}
+
+// CleanReport says what a CleanRule changed.
+type CleanReport struct {
+	File          string
+	Before, After int
+	Removed       []string
+	Renamed       [][2]string // old and new name, in file order
+	Missing       []string    // names to remove that were not found, sorted
+}
+
+// ProperRule says which concepts in one file of the library are proper
+// nouns. Every other concept in the file loses "proper_noun".
+type ProperRule struct {
+	File       string              `json:"file"`
+	Categories []string            `json:"categories"` // every concept in these
+	Names      map[string][]string `json:"names"`      // names, grouped by category
+}
+
+// ProperReport says what a ProperRule marked.
+type ProperReport struct {
+	File          string
+	Total, Marked int
+	Missing       []string // names that were not found, sorted
+}
+
+// LoadCleanRules reads a list of clean rules from a JSON file.
+func LoadCleanRules(path string) ([]CleanRule, error) {
+	var rules []CleanRule
+	if err := loadRules(path, &rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+// LoadProperRules reads a list of proper-noun rules from a JSON file.
+func LoadProperRules(path string) ([]ProperRule, error) {
+	var rules []ProperRule
+	if err := loadRules(path, &rules); err != nil {
+		return nil, err
+	}
+	return rules, nil
+}
+
+func loadRules(path string, v any) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading rules: %w", err)
+	}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		return fmt.Errorf("rules %s: %w", path, err)
+	}
+	return nil
+}
+
+// Clean applies each rule to its file in dir, rewriting the file.
+func Clean(dir string, rules []CleanRule) ([]CleanReport, error) {
+	var reports []CleanReport
+	for _, r := range rules {
+		var report CleanReport
+		err := rewrite(filepath.Join(dir, r.File), func(items []*object) []*object {
+			items, report = r.apply(items)
+			return items
+		})
+		if err != nil {
+			return reports, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// MarkProper applies each rule to its file in dir, rewriting the file.
+func MarkProper(dir string, rules []ProperRule) ([]ProperReport, error) {
+	var reports []ProperReport
+	for _, r := range rules {
+		var report ProperReport
+		err := rewrite(filepath.Join(dir, r.File), func(items []*object) []*object {
+			report = r.apply(items)
+			return items
+		})
+		if err != nil {
+			return reports, err
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// rewrite replaces the concept file at path with what edit makes of it.
+func rewrite(path string, edit func([]*object) []*object) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading concepts: %w", err)
+	}
+	items, err := decodeItems(b)
+	if err != nil {
+		return fmt.Errorf("concepts %s: %w", path, err)
+	}
+	return os.WriteFile(path, encodeItems(edit(items)), 0o644)
+}
+
+func (r CleanRule) apply(items []*object) ([]*object, CleanReport) {
+	remove := names(r.Remove)
+	report := CleanReport{File: r.File, Before: len(items)}
+	found := make(map[string]bool)
+	var kept []*object
+	for _, o := range items {
+		name, _ := o.name()
+		if remove[name] {
+			report.Removed = append(report.Removed, name)
+			found[name] = true
+			continue
+		}
+		if to, ok := r.Rename[name]; ok {
+			report.Renamed = append(report.Renamed, [2]string{name, to})
+			o.set("name", to)
+		}
+		kept = append(kept, o)
+	}
+	report.After = len(kept)
+	report.Missing = missing(remove, found)
+	return kept, report
+}
+
+func (r ProperRule) apply(items []*object) ProperReport {
+	proper := names(r.Names)
+	categories := make(map[string]bool, len(r.Categories))
+	for _, c := range r.Categories {
+		categories[c] = true
+	}
+	report := ProperReport{File: r.File, Total: len(items)}
+	found := make(map[string]bool)
+	for _, o := range items {
+		name, _ := o.name()
+		found[name] = true
+		category, _ := o.get("category")
+		c, _ := category.(string)
+		if categories[c] || proper[name] {
+			o.set("proper_noun", true)
+			report.Marked++
+		} else {
+			o.delete("proper_noun")
+		}
+	}
+	report.Missing = missing(proper, found)
+	return report
+}
+
+// names flattens grouped names into a set.
+func names(groups map[string][]string) map[string]bool {
+	set := make(map[string]bool)
+	for _, group := range groups {
+		for _, n := range group {
+			set[n] = true
+		}
+	}
+	return set
+}
+
+// missing returns the names in want that are not in found, sorted.
+func missing(want, found map[string]bool) []string {
+	var names []string
+	for n := range want {
+		if !found[n] {
+			names = append(names, n)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
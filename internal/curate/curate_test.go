@@ -0,0 +1,170 @@
+package curate
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestCleanAndMarkProper(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "concepts.json")
+	in := `[
+ {"name": "Gallon Jug", "category": "Object", "volume_m3": 0.0037854},
+ {"name": "Escalator (standard)", "category": "Object", "length_m": 6.0, "proper_noun": true},
+ {"name": "Eiffel Tower", "category": "Structure", "height_m": 330},
+ {"name": "Paris", "proper_noun": false, "category": "City", "area_m2": 1.054e8}
+]`
+	if err := os.WriteFile(path, []byte(in), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cleaned, err := Clean(dir, []CleanRule{{
+		File:   "concepts.json",
+		Remove: map[string][]string{"Unit-derived": {"Gallon Jug"}, "Obscure": {"Stapler"}},
+		Rename: map[string]string{"Escalator (standard)": "Escalator"},
+	}})
+	if err != nil {
+		t.Fatalf("Clean() error: %v", err)
+	}
+	wantClean := CleanReport{
+		File: "concepts.json", Before: 4, After: 3,
+		Removed: []string{"Gallon Jug"},
+		Renamed: [][2]string{{"Escalator (standard)", "Escalator"}},
+		Missing: []string{"Stapler"},
+	}
+	if len(cleaned) != 1 || !reflect.DeepEqual(cleaned[0], wantClean) {
+		t.Errorf("Clean() = %+v, want %+v", cleaned, wantClean)
+	}
+
+	marked, err := MarkProper(dir, []ProperRule{{
+		File:       "concepts.json",
+		Categories: []string{"City"},
+		Names:      map[string][]string{"Structure": {"Eiffel Tower", "Big Ben"}},
+	}})
+	if err != nil {
+		t.Fatalf("MarkProper() error: %v", err)
+	}
+	wantMarked := ProperReport{File: "concepts.json", Total: 3, Marked: 2, Missing: []string{"Big Ben"}}
+	if len(marked) != 1 || !reflect.DeepEqual(marked[0], wantMarked) {
+		t.Errorf("MarkProper() = %+v, want %+v", marked, wantMarked)
+	}
+
+	// As json.dump writes them: a kept proper_noun stays in place, a new
+	// one goes last.
+	want := `[
+  {
+    "name": "Escalator",
+    "category": "Object",
+    "length_m": 6.0
+  },
+  {
+    "name": "Eiffel Tower",
+    "category": "Structure",
+    "height_m": 330,
+    "proper_noun": true
+  },
+  {
+    "name": "Paris",
+    "proper_noun": true,
+    "category": "City",
+    "area_m2": 105400000.0
+  }
+]
+`
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != want {
+		t.Errorf("curated file =\n%s\nwant\n%s", got, want)
+	}
+}
+
+// TestRulesKeepLibrary applies the rules in tools/data to a copy of the
+// library and checks that they change nothing: the rules describe the
+// library as committed, so a concept added or renamed without updating them
+// fails here.
+func TestRulesKeepLibrary(t *testing.T) {
+	cleanRules, err := LoadCleanRules("../../tools/data/clean.json")
+	if err != nil {
+		t.Fatalf("LoadCleanRules() error: %v", err)
+	}
+	properRules, err := LoadProperRules("../../tools/data/proper_nouns.json")
+	if err != nil {
+		t.Fatalf("LoadProperRules() error: %v", err)
+	}
+	dir := t.TempDir()
+	var files []string
+	for _, r := range cleanRules {
+		files = append(files, r.File)
+	}
+	for _, r := range properRules {
+		if !slices.Contains(files, r.File) {
+			files = append(files, r.File)
+		}
+	}
+	for _, file := range files {
+		copyFile(t, filepath.Join("../data", file), filepath.Join(dir, file))
+	}
+
+	cleaned, err := Clean(dir, cleanRules)
+	if err != nil {
+		t.Fatalf("Clean() error: %v", err)
+	}
+	for _, r := range cleaned {
+		if len(r.Removed) > 0 || len(r.Renamed) > 0 {
+			t.Errorf("%s: clean removes %q and renames %q", r.File, r.Removed, r.Renamed)
+		}
+	}
+	marked, err := MarkProper(dir, properRules)
+	if err != nil {
+		t.Fatalf("MarkProper() error: %v", err)
+	}
+	for _, r := range marked {
+		if len(r.Missing) > 0 {
+			t.Errorf("%s: proper nouns not in the library: %q", r.File, r.Missing)
+		}
+	}
+
+	for _, file := range files {
+		want, err := os.ReadFile(filepath.Join("../data", file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := os.ReadFile(filepath.Join(dir, file))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, want) {
+			t.Errorf("%s changed; update tools/data to match the library", file)
+		}
+	}
+}
+
+func copyFile(t *testing.T, from, to string) {
+	t.Helper()
+	b, err := os.ReadFile(from)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Dir(to), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(to, b, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadRulesUnknownField(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rules.json")
+	if err := os.WriteFile(path, []byte(`[{"file": "a.json", "rmove": {}}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadCleanRules(path); err == nil {
+		t.Error("LoadCleanRules() with a misspelled field should fail")
+	}
+}
@@ -0,0 +1,258 @@
+package curate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// object is a JSON object that keeps its keys in file order, so a file can
+// be rewritten with only the intended changes.
+type object struct {
+	keys   []string
+	values map[string]any
+}
+
+func newObject() *object {
+	return &object{values: make(map[string]any)}
+}
+
+func (o *object) get(key string) (any, bool) {
+	v, ok := o.values[key]
+	return v, ok
+}
+
+// set replaces the value of key in place, or appends key if it is new.
+func (o *object) set(key string, v any) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = v
+}
+
+func (o *object) delete(key string) {
+	if _, ok := o.values[key]; !ok {
+		return
+	}
+	delete(o.values, key)
+	for i, k := range o.keys {
+		if k == key {
+			o.keys = append(o.keys[:i], o.keys[i+1:]...)
+			break
+		}
+	}
+}
+
+// name returns the "name" of a concept.
+func (o *object) name() (string, bool) {
+	v, _ := o.get("name")
+	s, ok := v.(string)
+	return s, ok
+}
+
+// decodeItems decodes a concept file: a list of objects. Values are nil,
+// bool, json.Number, string, []any or *object.
+func decodeItems(b []byte) ([]*object, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	v, err := decodeValue(dec)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("unexpected data after the list of concepts")
+	}
+	list, ok := v.([]any)
+	if !ok {
+		return nil, errors.New("want a list of concepts")
+	}
+	items := make([]*object, len(list))
+	for i, v := range list {
+		o, ok := v.(*object)
+		if !ok {
+			return nil, fmt.Errorf("entry %d: want an object", i+1)
+		}
+		if _, ok := o.name(); !ok {
+			return nil, fmt.Errorf("entry %d: missing name", i+1)
+		}
+		items[i] = o
+	}
+	return items, nil
+}
+
+func decodeValue(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok := tok.(type) {
+	case json.Delim:
+		if tok == '{' {
+			return decodeObject(dec)
+		}
+		list := []any{}
+		for dec.More() {
+			v, err := decodeValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		_, err := dec.Token()
+		return list, err
+	case json.Number:
+		// Python would write a float too large for 64 bits as Infinity,
+		// which is not JSON.
+		if f, err := tok.Float64(); err != nil && math.IsInf(f, 0) {
+			return nil, fmt.Errorf("number %s out of range", tok)
+		}
+	}
+	return tok, nil
+}
+
+// decodeObject decodes the members of an object after its opening brace.
+// A repeated key keeps its first position and its last value, as in
+// Python.
+func decodeObject(dec *json.Decoder) (*object, error) {
+	o := newObject()
+	for dec.More() {
+		key, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		v, err := decodeValue(dec)
+		if err != nil {
+			return nil, err
+		}
+		o.set(key.(string), v)
+	}
+	_, err := dec.Token()
+	return o, err
+}
+
+// encodeItems writes items as Python's json.dump(items, f, indent=2,
+// ensure_ascii=False) followed by a newline, which is how the library
+// files have always been written.
+func encodeItems(items []*object) []byte {
+	list := make([]any, len(items))
+	for i, o := range items {
+		list[i] = o
+	}
+	var b bytes.Buffer
+	encodeValue(&b, list, 0)
+	b.WriteByte('\n')
+	return b.Bytes()
+}
+
+func encodeValue(b *bytes.Buffer, v any, depth int) {
+	newline := func(depth int) {
+		b.WriteByte('\n')
+		b.WriteString(strings.Repeat("  ", depth))
+	}
+	switch v := v.(type) {
+	case nil:
+		b.WriteString("null")
+	case bool:
+		b.WriteString(strconv.FormatBool(v))
+	case json.Number:
+		b.WriteString(pyNumber(v))
+	case string:
+		b.WriteString(pyString(v))
+	case []any:
+		if len(v) == 0 {
+			b.WriteString("[]")
+			return
+		}
+		b.WriteByte('[')
+		for i, e := range v {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			newline(depth + 1)
+			encodeValue(b, e, depth+1)
+		}
+		newline(depth)
+		b.WriteByte(']')
+	case *object:
+		if len(v.keys) == 0 {
+			b.WriteString("{}")
+			return
+		}
+		b.WriteByte('{')
+		for i, k := range v.keys {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			newline(depth + 1)
+			b.WriteString(pyString(k))
+			b.WriteString(": ")
+			encodeValue(b, v.values[k], depth+1)
+		}
+		newline(depth)
+		b.WriteByte('}')
+	default:
+		panic(fmt.Sprintf("curate: cannot encode %T", v))
+	}
+}
+
+// pyNumber writes n as Python writes the int or float json.load reads it
+// as: integers keep their digits, floats use the shortest repr, as in
+// 150000.0, 0.0001, 1e-05 and 6.39e+23.
+func pyNumber(n json.Number) string {
+	s := n.String()
+	if !strings.ContainsAny(s, ".eE") {
+		if s == "-0" {
+			return "0"
+		}
+		return s
+	}
+	f, _ := strconv.ParseFloat(s, 64)
+	e := strconv.FormatFloat(f, 'e', -1, 64)
+	exp, _ := strconv.Atoi(e[strings.IndexByte(e, 'e')+1:])
+	if exp < -4 || exp >= 16 {
+		return e
+	}
+	fixed := strconv.FormatFloat(f, 'f', -1, 64)
+	if !strings.Contains(fixed, ".") {
+		fixed += ".0"
+	}
+	return fixed
+}
+
+// pyString quotes s as Python does without ensure_ascii: only quotes,
+// backslashes and control characters are escaped.
+func pyString(s string) string {
+	var b strings.Builder
+	b.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b.WriteString(`\"`)
+		case '\\':
+			b.WriteString(`\\`)
+		case '\b':
+			b.WriteString(`\b`)
+		case '\f':
+			b.WriteString(`\f`)
+		case '\n':
+			b.WriteString(`\n`)
+		case '\r':
+			b.WriteString(`\r`)
+		case '\t':
+			b.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&b, `\u%04x`, r)
+			} else {
+				b.WriteRune(r)
+			}
+		}
+	}
+	b.WriteByte('"')
+	return b.String()
+}
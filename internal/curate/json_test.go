@@ -0,0 +1,74 @@
+package curate
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"testing"
+)
+
+// The library files were written by Python, so reading and writing them
+// back must not change a byte.
+func TestRoundTripLibrary(t *testing.T) {
+	for _, file := range []string{"world_measurements.json", "world_durations.json", "world_prices.json"} {
+		b, err := os.ReadFile("../data/" + file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		items, err := decodeItems(b)
+		if err != nil {
+			t.Fatalf("%s: %v", file, err)
+		}
+		if !bytes.Equal(encodeItems(items), b) {
+			t.Errorf("%s changed in a round trip", file)
+		}
+	}
+}
+
+func TestPyNumber(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"150000", "150000"},
+		{"-0", "0"},
+		{"123456789012345678901234567890", "123456789012345678901234567890"},
+		{"150000.0", "150000.0"},
+		{"1E5", "100000.0"},
+		{"1.10", "1.1"},
+		{"-0.0", "-0.0"},
+		{"0.0001", "0.0001"},
+		{"0.00001", "1e-05"},
+		{"1e15", "1000000000000000.0"},
+		{"1e16", "1e+16"},
+		{"6.39e23", "6.39e+23"},
+		{"2.5e-7", "2.5e-07"},
+	}
+	for _, tt := range tests {
+		if got := pyNumber(json.Number(tt.in)); got != tt.want {
+			t.Errorf("pyNumber(%s) = %s, want %s", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestPyString(t *testing.T) {
+	in := "tab\there \"q\" \\ \x01 é 😀 </>"
+	want := `"tab\there \"q\" \\ \u0001 é 😀 </>"`
+	if got := pyString(in); got != want {
+		t.Errorf("pyString(%q) = %s, want %s", in, got, want)
+	}
+}
+
+func TestDecodeItems(t *testing.T) {
+	items, err := decodeItems([]byte(`[{"name": "a", "x": [], "y": {}, "name": "b"}]`))
+	if err != nil {
+		t.Fatalf("decodeItems() error: %v", err)
+	}
+	want := "[\n  {\n    \"name\": \"b\",\n    \"x\": [],\n    \"y\": {}\n  }\n]\n"
+	if got := string(encodeItems(items)); got != want {
+		t.Errorf("encodeItems() = %q, want %q", got, want)
+	}
+
+	for _, in := range []string{`{"name": "a"}`, `[{"category": "Object"}]`, `[1]`, `[{"name": "a", "x": 1e400}]`, `[] []`} {
+		if _, err := decodeItems([]byte(in)); err == nil {
+			t.Errorf("decodeItems(%s) should fail", in)
+		}
+	}
+}
@@ -1,8 +1,15 @@
 package data
 
 import (
+	"bytes"
 	_ "embed"
 	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 )
 
 //go:embed world_measurements.json
@@ -11,18 +18,44 @@ var measurementsJSON []byte
 //go:embed world_durations.json
 var durationsJSON []byte
 
+//go:embed world_prices.json
+var pricesJSON []byte
+
 type Concept struct {
-	Name       string   `json:"name"`
-	Category   string   `json:"category"`
-	ProperNoun bool     `json:"proper_noun,omitempty"`
-	LengthM    *float64 `json:"length_m,omitempty"`
-	HeightM    *float64 `json:"height_m,omitempty"`
-	WidthM     *float64 `json:"width_m,omitempty"`
-	WeightKg   *float64 `json:"weight_kg,omitempty"`
-	VolumeM3   *float64 `json:"volume_m3,omitempty"`
-	AreaM2     *float64 `json:"area_m2,omitempty"`
-	DistanceM  *float64 `json:"distance_m,omitempty"`
-	DurationS  *float64 `json:"duration_s,omitempty"`
+	Name       string `json:"name"`
+	Category   string `json:"category"`
+	ProperNoun bool   `json:"proper_noun,omitempty"`
+
+	// How sentences name the concept, when not by Name and its English
+	// plural: SingularDisplay replaces Name ("Human heartbeat" for "Human
+	// heartbeat (one beat)"), Plural is the plural of that ("Mice"), and
+	// Countable false marks things that are not counted ("Sand").
+	SingularDisplay string `json:"singular_display,omitempty"`
+	Plural          string `json:"plural,omitempty"`
+	Countable       *bool  `json:"countable,omitempty"`
+
+	LengthM      *float64 `json:"length_m,omitempty"`
+	HeightM      *float64 `json:"height_m,omitempty"`
+	WidthM       *float64 `json:"width_m,omitempty"`
+	WeightKg     *float64 `json:"weight_kg,omitempty"`
+	VolumeM3     *float64 `json:"volume_m3,omitempty"`
+	AreaM2       *float64 `json:"area_m2,omitempty"`
+	DistanceM    *float64 `json:"distance_m,omitempty"`
+	DurationS    *float64 `json:"duration_s,omitempty"`
+	SpeedMPS     *float64 `json:"speed_mps,omitempty"`
+	EnergyJ      *float64 `json:"energy_j,omitempty"`
+	PowerW       *float64 `json:"power_w,omitempty"`
+	DataBytes    *float64 `json:"data_bytes,omitempty"`
+	PriceUSD     *float64 `json:"price_usd,omitempty"`
+	TemperatureK *float64 `json:"temperature_k,omitempty"`
+	Count        *float64 `json:"count,omitempty"`
+
+	// Provenance, for checking the measurements: Notes qualify them ("At
+	// 70 bpm resting"), Source is a URL or citation and AsOf the date the
+	// measurements were true, such as "2024" or "2024-06".
+	Notes  string `json:"notes,omitempty"`
+	Source string `json:"source,omitempty"`
+	AsOf   string `json:"as_of,omitempty"`
 }
 
 func (c Concept) ValueFor(dimension string) (float64, bool) {
@@ -44,6 +77,20 @@ func (c Concept) ValueFor(dimension string) (float64, bool) {
 		p = c.DistanceM
 	case "duration":
 		p = c.DurationS
+	case "speed":
+		p = c.SpeedMPS
+	case "energy":
+		p = c.EnergyJ
+	case "power":
+		p = c.PowerW
+	case "data":
+		p = c.DataBytes
+	case "money":
+		p = c.PriceUSD
+	case "temperature":
+		p = c.TemperatureK
+	case "count":
+		p = c.Count
 	default:
 		return 0, false
 	}
@@ -54,10 +101,34 @@ func (c Concept) ValueFor(dimension string) (float64, bool) {
 }
 
 type rawDuration struct {
-	Name       string  `json:"name"`
-	Category   string  `json:"category"`
-	ProperNoun bool    `json:"proper_noun,omitempty"`
-	DurationS  float64 `json:"duration_s"`
+	Name            string  `json:"name"`
+	Category        string  `json:"category"`
+	ProperNoun      bool    `json:"proper_noun,omitempty"`
+	DurationS       float64 `json:"duration_s"`
+	SingularDisplay string  `json:"singular_display,omitempty"`
+	Plural          string  `json:"plural,omitempty"`
+	Countable       *bool   `json:"countable,omitempty"`
+	Notes           string  `json:"notes,omitempty"`
+	Source          string  `json:"source,omitempty"`
+	AsOf            string  `json:"as_of,omitempty"`
+}
+
+// rawPrice is an entry in a prices file. Prices are kept apart from the
+// measurements so they can be replaced with a local file; an entry whose
+// name matches an existing concept adds a price to it, any other entry
+// becomes a new concept. Notes, Source and AsOf fill in those the concept
+// lacks.
+type rawPrice struct {
+	Name            string  `json:"name"`
+	Category        string  `json:"category"`
+	ProperNoun      bool    `json:"proper_noun,omitempty"`
+	PriceUSD        float64 `json:"price_usd"`
+	SingularDisplay string  `json:"singular_display,omitempty"`
+	Plural          string  `json:"plural,omitempty"`
+	Countable       *bool   `json:"countable,omitempty"`
+	Notes           string  `json:"notes,omitempty"`
+	Source          string  `json:"source,omitempty"`
+	AsOf            string  `json:"as_of,omitempty"`
 }
 
 func loadMeasurements() ([]Concept, error) {
@@ -75,16 +146,208 @@ func loadDurations() ([]Concept, error) {
 	for i, r := range raw {
 		dur := r.DurationS
 		concepts[i] = Concept{
-			Name:       r.Name,
-			Category:   r.Category,
-			ProperNoun: r.ProperNoun,
-			DurationS:  &dur,
+			Name:            r.Name,
+			Category:        r.Category,
+			ProperNoun:      r.ProperNoun,
+			DurationS:       &dur,
+			SingularDisplay: r.SingularDisplay,
+			Plural:          r.Plural,
+			Countable:       r.Countable,
+			Notes:           r.Notes,
+			Source:          r.Source,
+			AsOf:            r.AsOf,
+		}
+	}
+	return concepts, nil
+}
+
+func parsePrices(b []byte) ([]rawPrice, error) {
+	var raw []rawPrice
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return nil, err
+	}
+	for _, r := range raw {
+		if r.Name == "" {
+			return nil, fmt.Errorf("price entry without a name")
+		}
+		if !(r.PriceUSD > 0) {
+			return nil, fmt.Errorf("price for %q must be positive, got %g", r.Name, r.PriceUSD)
+		}
+	}
+	return raw, nil
+}
+
+// mergePrices sets PriceUSD on concepts named in prices and appends the
+// rest as new concepts.
+func mergePrices(concepts []Concept, prices []rawPrice) []Concept {
+	byName := make(map[string]int, len(concepts))
+	for i, c := range concepts {
+		byName[c.Name] = i
+	}
+	for _, r := range prices {
+		price := r.PriceUSD
+		if i, ok := byName[r.Name]; ok {
+			c := &concepts[i]
+			c.PriceUSD = &price
+			fill(&c.Notes, r.Notes)
+			fill(&c.Source, r.Source)
+			fill(&c.AsOf, r.AsOf)
+			continue
+		}
+		concepts = append(concepts, Concept{
+			Name:            r.Name,
+			Category:        r.Category,
+			ProperNoun:      r.ProperNoun,
+			PriceUSD:        &price,
+			SingularDisplay: r.SingularDisplay,
+			Plural:          r.Plural,
+			Countable:       r.Countable,
+			Notes:           r.Notes,
+			Source:          r.Source,
+			AsOf:            r.AsOf,
+		})
+		byName[r.Name] = len(concepts) - 1
+	}
+	return concepts
+}
+
+// fill sets *field to v if it is empty.
+func fill(field *string, v string) {
+	if *field == "" {
+		*field = v
+	}
+}
+
+// readConceptPaths reads the concept files at paths in order.
+func readConceptPaths(paths []string) ([]Concept, error) {
+	files, err := expandConceptPaths(paths)
+	if err != nil {
+		return nil, err
+	}
+	var concepts []Concept
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("reading concepts: %w", err)
+		}
+		cs, err := parseConcepts(b)
+		if err != nil {
+			return nil, fmt.Errorf("concepts %s: %w", file, err)
+		}
+		concepts = append(concepts, cs...)
+	}
+	return concepts, nil
+}
+
+// expandConceptPaths replaces each directory among paths with its .json
+// files in name order.
+func expandConceptPaths(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading concepts: %w", err)
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		matches, err := filepath.Glob(filepath.Join(path, "*.json"))
+		if err != nil {
+			return nil, fmt.Errorf("reading concepts: %w", err)
 		}
+		sort.Strings(matches)
+		files = append(files, matches...)
+	}
+	return files, nil
+}
+
+// decodeConcepts decodes a list of concepts in the schema of the embedded
+// measurements, rejecting unknown fields so a misspelled dimension is not
+// silently dropped.
+func decodeConcepts(b []byte) ([]Concept, error) {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.DisallowUnknownFields()
+	var concepts []Concept
+	if err := dec.Decode(&concepts); err != nil {
+		return nil, err
 	}
 	return concepts, nil
 }
 
+// parseConcepts decodes a concept file, failing on the first malformed or
+// duplicate entry.
+func parseConcepts(b []byte) ([]Concept, error) {
+	concepts, err := decodeConcepts(b)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]bool, len(concepts))
+	for i, c := range concepts {
+		if msg := malformed(c); msg != "" {
+			if c.Name != "" {
+				msg = fmt.Sprintf("%q: %s", c.Name, msg)
+			}
+			return nil, fmt.Errorf("entry %d: %s", i+1, msg)
+		}
+		if seen[c.Name] {
+			return nil, fmt.Errorf("entry %d: duplicate name %q", i+1, c.Name)
+		}
+		seen[c.Name] = true
+	}
+	return concepts, nil
+}
+
+// malformed returns what makes c unusable: a missing name or category, no
+// measurements, or a measurement that is not a positive number. It returns
+// "" for a usable concept.
+func malformed(c Concept) string {
+	if strings.TrimSpace(c.Name) == "" {
+		return "missing name"
+	}
+	if strings.TrimSpace(c.Category) == "" {
+		return "missing category"
+	}
+	measured := false
+	for _, dim := range dimensions {
+		v, ok := c.ValueFor(dim)
+		if !ok {
+			continue
+		}
+		if !(v > 0) || math.IsInf(v, 0) {
+			return fmt.Sprintf("%s must be positive, got %g", fields[dim], v)
+		}
+		measured = true
+	}
+	if !measured {
+		return "no measurements"
+	}
+	return ""
+}
+
+// mergeConcepts replaces concepts with the extra concept of the same name
+// and appends the rest.
+func mergeConcepts(concepts, extra []Concept) []Concept {
+	byName := make(map[string]int, len(concepts))
+	for i, c := range concepts {
+		byName[c.Name] = i
+	}
+	for _, c := range extra {
+		if i, ok := byName[c.Name]; ok {
+			concepts[i] = c
+			continue
+		}
+		concepts = append(concepts, c)
+		byName[c.Name] = len(concepts) - 1
+	}
+	return concepts
+}
+
 func LoadConcepts() ([]Concept, error) {
+	return loadConcepts(storeConfig{})
+}
+
+func loadConcepts(cfg storeConfig) ([]Concept, error) {
 	measurements, err := loadMeasurements()
 	if err != nil {
 		return nil, err
@@ -93,5 +356,22 @@ func LoadConcepts() ([]Concept, error) {
 	if err != nil {
 		return nil, err
 	}
-	return append(measurements, durations...), nil
+
+	b := pricesJSON
+	if cfg.pricesFile != "" {
+		if b, err = os.ReadFile(cfg.pricesFile); err != nil {
+			return nil, fmt.Errorf("reading prices: %w", err)
+		}
+	}
+	prices, err := parsePrices(b)
+	if err != nil {
+		return nil, fmt.Errorf("parsing prices: %w", err)
+	}
+	concepts := mergePrices(append(measurements, durations...), prices)
+
+	extra, err := readConceptPaths(cfg.conceptPaths)
+	if err != nil {
+		return nil, err
+	}
+	return mergeConcepts(concepts, extra), nil
 }
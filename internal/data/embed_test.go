@@ -119,6 +119,9 @@ func TestLoadConceptsHasDurations(t *testing.T) {
 			if c.Category != "Sports" {
 				t.Errorf("Marathon world record category = %q, want Sports", c.Category)
 			}
+			if c.Notes != "Kelvin Kiptum, 1:57:58 (2023)" {
+				t.Errorf("Marathon world record notes = %q, want the file's notes", c.Notes)
+			}
 		}
 	}
 	if !found {
@@ -126,6 +129,35 @@ func TestLoadConceptsHasDurations(t *testing.T) {
 	}
 }
 
+func TestLoadConceptsDisplayFields(t *testing.T) {
+	concepts, err := LoadConcepts()
+	if err != nil {
+		t.Fatalf("LoadConcepts() error: %v", err)
+	}
+	var heartbeat, mars bool
+	for _, c := range concepts {
+		switch c.Name {
+		case "Human heartbeat (one beat)":
+			heartbeat = true
+			if c.SingularDisplay != "Human heartbeat" {
+				t.Errorf("heartbeat singular_display = %q, want Human heartbeat", c.SingularDisplay)
+			}
+		case "Mars":
+			mars = true
+			if !c.ProperNoun || c.Countable != nil {
+				t.Errorf("Mars should be a proper noun and countable, got %+v", c)
+			}
+		case "Soccer Field":
+			if c.Countable != nil || c.Plural != "" {
+				t.Errorf("Soccer Field has display fields it does not need: %+v", c)
+			}
+		}
+	}
+	if !heartbeat || !mars {
+		t.Error("heartbeat or Mars not found in concepts")
+	}
+}
+
 func TestLoadDurationsDoNotHaveOtherDimensions(t *testing.T) {
 	durations, err := loadDurations()
 	if err != nil {
@@ -152,3 +184,68 @@ func TestLoadMeasurementsDoNotHaveDuration(t *testing.T) {
 		}
 	}
 }
+
+func TestLoadConceptsHasSpeeds(t *testing.T) {
+	concepts, err := LoadConcepts()
+	if err != nil {
+		t.Fatalf("LoadConcepts() error: %v", err)
+	}
+	for _, c := range concepts {
+		if c.Name == "Cheetah" {
+			if v, ok := c.ValueFor("speed"); !ok || v != 29 {
+				t.Errorf("Cheetah speed = %v, %v, want 29", v, ok)
+			}
+			return
+		}
+	}
+	t.Error("Cheetah not found")
+}
+
+func TestLoadConceptsHasPrices(t *testing.T) {
+	concepts, err := LoadConcepts()
+	if err != nil {
+		t.Fatalf("LoadConcepts() error: %v", err)
+	}
+	found := map[string]bool{}
+	for _, c := range concepts {
+		switch c.Name {
+		case "Median US House":
+			// Only in world_prices.json.
+			found[c.Name] = true
+			if v, ok := c.ValueFor("money"); !ok || v != 420000 {
+				t.Errorf("Median US House money = %v, %v, want 420000", v, ok)
+			}
+		case "Boeing 747 (Jumbo Jet)":
+			// Price merged into an existing measurement concept.
+			found[c.Name] = true
+			if _, ok := c.ValueFor("money"); !ok {
+				t.Error("Boeing 747 (Jumbo Jet) has no price")
+			}
+			if _, ok := c.ValueFor("length"); !ok {
+				t.Error("Boeing 747 (Jumbo Jet) lost its length")
+			}
+		}
+	}
+	if len(found) != 2 {
+		t.Errorf("found %v, want both priced concepts", found)
+	}
+}
+
+func TestLoadConceptsHasCounts(t *testing.T) {
+	concepts, err := LoadConcepts()
+	if err != nil {
+		t.Fatalf("LoadConcepts() error: %v", err)
+	}
+	for _, c := range concepts {
+		if c.Name == "London" {
+			if v, ok := c.ValueFor("count"); !ok || v < 1e6 {
+				t.Errorf("London count = %v, %v, want its population", v, ok)
+			}
+			if _, ok := c.ValueFor("area"); !ok {
+				t.Error("London lost its area")
+			}
+			return
+		}
+	}
+	t.Error("London not found")
+}
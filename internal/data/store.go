@@ -1,6 +1,11 @@
 package data
 
-import "sort"
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
 
 type IndexEntry struct {
 	Concept *Concept
@@ -39,19 +44,80 @@ type ConceptStore struct {
 	ByDimension map[string]*DimensionIndex
 }
 
-var dimensions = []string{"length", "height", "width", "weight", "volume", "area", "distance", "duration"}
+var dimensions = []string{"length", "height", "width", "weight", "volume", "area", "distance", "duration", "speed", "energy", "power", "data", "money", "temperature", "count"}
 
-func NewConceptStore() (*ConceptStore, error) {
-	measurements, err := loadMeasurements()
-	if err != nil {
-		return nil, err
+// nonAdditive lists dimensions whose values do not add up when concepts are
+// counted: two cheetahs are no faster than one. A count is compared with the
+// input number directly rather than multiplied by it.
+var nonAdditive = map[string]bool{"speed": true, "temperature": true, "count": true}
+
+// intervals lists dimensions where only differences between values mean
+// anything to a reader: 20 °C is not "twice as warm" as 10 °C.
+var intervals = map[string]bool{"temperature": true}
+
+// Dimensions returns the names of every indexed dimension.
+func Dimensions() []string {
+	return append([]string(nil), dimensions...)
+}
+
+// Additive reports whether count copies of a concept measure count times
+// as much in dimension.
+func Additive(dimension string) bool {
+	return !nonAdditive[dimension]
+}
+
+// Interval reports whether concepts in dimension should be compared by
+// difference rather than by ratio.
+func Interval(dimension string) bool {
+	return intervals[dimension]
+}
+
+type storeConfig struct {
+	pricesFile   string
+	conceptPaths []string
+}
+
+// StoreOption configures NewConceptStore.
+type StoreOption func(*storeConfig)
+
+// WithPricesFile replaces the embedded prices with the JSON file at path.
+func WithPricesFile(path string) StoreOption {
+	return func(c *storeConfig) { c.pricesFile = path }
+}
+
+// WithConceptFiles adds the concepts in JSON files, in the schema of the
+// embedded measurements, to the library. A path may be a directory, whose
+// .json files are read in name order. A concept named like one already
+// loaded, built in or from an earlier file, replaces it entirely.
+func WithConceptFiles(paths ...string) StoreOption {
+	return func(c *storeConfig) { c.conceptPaths = append(c.conceptPaths, paths...) }
+}
+
+func NewConceptStore(opts ...StoreOption) (*ConceptStore, error) {
+	var cfg storeConfig
+	for _, opt := range opts {
+		opt(&cfg)
 	}
-	durations, err := loadDurations()
+	all, err := loadConcepts(cfg)
 	if err != nil {
 		return nil, err
 	}
+	return newConceptStore(all), nil
+}
+
+// Filter returns a new store containing only the concepts for which keep
+// returns true.
+func (s *ConceptStore) Filter(keep func(Concept) bool) *ConceptStore {
+	var kept []Concept
+	for _, c := range s.All {
+		if keep(c) {
+			kept = append(kept, c)
+		}
+	}
+	return newConceptStore(kept)
+}
 
-	all := append(measurements, durations...)
+func newConceptStore(all []Concept) *ConceptStore {
 	byDim := make(map[string]*DimensionIndex, len(dimensions))
 
 	for _, dim := range dimensions {
@@ -69,5 +135,48 @@ func NewConceptStore() (*ConceptStore, error) {
 		byDim[dim] = &DimensionIndex{Entries: entries}
 	}
 
-	return &ConceptStore{All: all, ByDimension: byDim}, nil
+	return &ConceptStore{All: all, ByDimension: byDim}
+}
+
+// ErrUnknownConcept is returned (wrapped) by Lookup when no concept matches.
+var ErrUnknownConcept = errors.New("unknown concept")
+
+// Lookup finds a concept by name. An exact, case-insensitive match wins;
+// otherwise the shortest name containing every word of name is returned, so
+// "iphone" finds "Smartphone (iPhone 14)".
+func (s *ConceptStore) Lookup(name string) (*Concept, error) {
+	query := strings.ToLower(strings.TrimSpace(name))
+	if query == "" {
+		return nil, fmt.Errorf("%w: empty name", ErrUnknownConcept)
+	}
+	words := strings.Fields(query)
+
+	var best *Concept
+	for i := range s.All {
+		c := &s.All[i]
+		candidate := strings.ToLower(c.Name)
+		if candidate == query {
+			return c, nil
+		}
+		if !containsAll(candidate, words) {
+			continue
+		}
+		if best == nil || len(c.Name) < len(best.Name) ||
+			(len(c.Name) == len(best.Name) && c.Name < best.Name) {
+			best = c
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownConcept, name)
+	}
+	return best, nil
+}
+
+func containsAll(s string, words []string) bool {
+	for _, w := range words {
+		if !strings.Contains(s, w) {
+			return false
+		}
+	}
+	return true
 }
@@ -2,6 +2,9 @@ package data
 
 import (
 	"math"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -136,3 +139,232 @@ func TestConceptStoreDurationIndex(t *testing.T) {
 		t.Error("Marathon world record not found in duration index")
 	}
 }
+
+func TestConceptStoreFilter(t *testing.T) {
+	store, err := NewConceptStore()
+	if err != nil {
+		t.Fatalf("NewConceptStore() error: %v", err)
+	}
+	animals := store.Filter(func(c Concept) bool { return c.Category == "Animal" })
+	if len(animals.All) == 0 {
+		t.Fatal("filtered store is empty")
+	}
+	if len(animals.All) >= len(store.All) {
+		t.Errorf("filtered store has %d concepts, want fewer than %d", len(animals.All), len(store.All))
+	}
+	for dim, idx := range animals.ByDimension {
+		for _, e := range idx.Entries {
+			if e.Concept.Category != "Animal" {
+				t.Errorf("dimension %q: %q has category %q, want Animal", dim, e.Concept.Name, e.Concept.Category)
+			}
+		}
+	}
+	if _, ok := animals.ByDimension["duration"]; !ok {
+		t.Error("filtered store should still have an index for every dimension")
+	}
+}
+
+func TestDimensions(t *testing.T) {
+	dims := Dimensions()
+	if len(dims) == 0 {
+		t.Fatal("Dimensions() is empty")
+	}
+	dims[0] = "mutated"
+	if Dimensions()[0] == "mutated" {
+		t.Error("Dimensions() should return a copy")
+	}
+}
+
+func TestLookup(t *testing.T) {
+	store, err := NewConceptStore()
+	if err != nil {
+		t.Fatalf("NewConceptStore() error: %v", err)
+	}
+	tests := []struct {
+		query string
+		want  string
+	}{
+		{"African Elephant", "African Elephant"},
+		{"african elephant", "African Elephant"},
+		{"iPhone", "Smartphone (iPhone 14)"},
+		{"  giraffe ", "Giraffe"},
+		{"blue whale", "Blue Whale"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			c, err := store.Lookup(tt.query)
+			if err != nil {
+				t.Fatalf("Lookup(%q) error: %v", tt.query, err)
+			}
+			if c.Name != tt.want {
+				t.Errorf("Lookup(%q) = %q, want %q", tt.query, c.Name, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookupNotFound(t *testing.T) {
+	store, err := NewConceptStore()
+	if err != nil {
+		t.Fatalf("NewConceptStore() error: %v", err)
+	}
+	for _, q := range []string{"", "flux capacitor"} {
+		if _, err := store.Lookup(q); err == nil {
+			t.Errorf("Lookup(%q) should fail", q)
+		}
+	}
+}
+
+func TestAdditive(t *testing.T) {
+	if !Additive("weight") {
+		t.Error("weight should be additive")
+	}
+	if Additive("speed") {
+		t.Error("speed should not be additive")
+	}
+	if Additive("temperature") {
+		t.Error("temperature should not be additive")
+	}
+}
+
+func TestInterval(t *testing.T) {
+	if !Interval("temperature") {
+		t.Error("temperature should be an interval dimension")
+	}
+	if Interval("weight") {
+		t.Error("weight should not be an interval dimension")
+	}
+}
+
+func TestWithPricesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prices.json")
+	prices := `[
+  {"name": "Cheetah", "category": "Animal", "price_usd": 15000, "source": "Exotic pet dealers", "as_of": "2025"},
+  {"name": "Espresso", "category": "Food", "price_usd": 3}
+]`
+	if err := os.WriteFile(path, []byte(prices), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	store, err := NewConceptStore(WithPricesFile(path))
+	if err != nil {
+		t.Fatalf("NewConceptStore() error: %v", err)
+	}
+	entries := store.ByDimension["money"].Entries
+	if len(entries) != 2 {
+		t.Fatalf("money entries = %d, want 2 (the file replaces the built-in prices)", len(entries))
+	}
+	if entries[0].Concept.Name != "Espresso" || entries[1].Concept.Name != "Cheetah" {
+		t.Errorf("money entries = %q, %q, want Espresso, Cheetah", entries[0].Concept.Name, entries[1].Concept.Name)
+	}
+	if _, ok := entries[1].Concept.ValueFor("speed"); !ok {
+		t.Error("Cheetah lost its speed when priced")
+	}
+	if c := entries[1].Concept; c.Source != "Exotic pet dealers" || c.AsOf != "2025" {
+		t.Errorf("Cheetah source, as_of = %q, %q, want the price's", c.Source, c.AsOf)
+	}
+}
+
+func TestWithPricesFileInvalid(t *testing.T) {
+	dir := t.TempDir()
+	bad := filepath.Join(dir, "bad.json")
+	if err := os.WriteFile(bad, []byte(`[{"name": "Espresso", "price_usd": -3}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range []string{bad, filepath.Join(dir, "missing.json")} {
+		if _, err := NewConceptStore(WithPricesFile(path)); err == nil {
+			t.Errorf("NewConceptStore(WithPricesFile(%q)) should fail", path)
+		}
+	}
+}
+
+func TestWithConceptFiles(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "warehouse.json")
+	if err := os.WriteFile(file, []byte(`[
+  {"name": "Our Warehouse", "category": "Structure", "length_m": 240, "area_m2": 36000,
+   "source": "Site plan rev. C", "as_of": "2026-01"},
+  {"name": "Cheetah", "category": "Animal", "weight_kg": 1234}
+]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	lib := filepath.Join(dir, "lib")
+	if err := os.Mkdir(lib, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	for name, body := range map[string]string{
+		"a.json":    `[{"name": "SKU 1001", "category": "Object", "weight_kg": 2.5}]`,
+		"b.json":    `[{"name": "SKU 1001", "category": "Object", "weight_kg": 3}]`,
+		"notes.txt": `not concepts`,
+	} {
+		if err := os.WriteFile(filepath.Join(lib, name), []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	store, err := NewConceptStore(WithConceptFiles(file, lib))
+	if err != nil {
+		t.Fatalf("NewConceptStore() error: %v", err)
+	}
+	warehouse, err := store.Lookup("Our Warehouse")
+	if err != nil {
+		t.Fatalf("Lookup(Our Warehouse) error: %v", err)
+	}
+	if v, _ := warehouse.ValueFor("area"); v != 36000 {
+		t.Errorf("Our Warehouse area = %g, want 36000", v)
+	}
+	if warehouse.Source != "Site plan rev. C" || warehouse.AsOf != "2026-01" {
+		t.Errorf("Our Warehouse source, as_of = %q, %q, want the file's", warehouse.Source, warehouse.AsOf)
+	}
+
+	cheetah, err := store.Lookup("Cheetah")
+	if err != nil {
+		t.Fatalf("Lookup(Cheetah) error: %v", err)
+	}
+	if v, _ := cheetah.ValueFor("weight"); v != 1234 {
+		t.Errorf("Cheetah weight = %g, want 1234 from the file", v)
+	}
+	if _, ok := cheetah.ValueFor("speed"); ok {
+		t.Error("Cheetah kept its built-in speed, want the file's entry to replace it")
+	}
+
+	sku, err := store.Lookup("SKU 1001")
+	if err != nil {
+		t.Fatalf("Lookup(SKU 1001) error: %v", err)
+	}
+	if v, _ := sku.ValueFor("weight"); v != 3 {
+		t.Errorf("SKU 1001 weight = %g, want 3 from the later file", v)
+	}
+}
+
+func TestWithConceptFilesInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		body    string
+		wantErr string
+	}{
+		{"syntax", `[{"name": "Widget",}]`, "invalid character"},
+		{"unknown field", `[{"name": "Widget", "category": "Object", "weight_lbs": 2}]`, `unknown field "weight_lbs"`},
+		{"missing name", `[{"category": "Object", "weight_kg": 2}]`, "entry 1: missing name"},
+		{"missing category", `[{"name": "Widget", "weight_kg": 2}]`, `"Widget": missing category`},
+		{"no measurements", `[{"name": "Widget", "category": "Object"}]`, `"Widget": no measurements`},
+		{"non-positive", `[{"name": "Widget", "category": "Object", "weight_kg": -2}]`, "weight_kg must be positive"},
+		{"duplicate", `[{"name": "W", "category": "Object", "weight_kg": 2}, {"name": "W", "category": "Object", "weight_kg": 3}]`, `entry 2: duplicate name "W"`},
+	}
+	dir := t.TempDir()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(dir, strings.ReplaceAll(tt.name, " ", "_")+".json")
+			if err := os.WriteFile(path, []byte(tt.body), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			_, err := NewConceptStore(WithConceptFiles(path))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) || !strings.Contains(err.Error(), path) {
+				t.Errorf("NewConceptStore() error = %v, want one naming %s and containing %q", err, path, tt.wantErr)
+			}
+		})
+	}
+
+	if _, err := NewConceptStore(WithConceptFiles(filepath.Join(dir, "missing.json"))); err == nil {
+		t.Error("NewConceptStore() with a missing file should fail")
+	}
+}
@@ -0,0 +1,218 @@
+package data
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// Problem is something Validate found wrong with a concept.
+type Problem struct {
+	File    string // empty for concepts not read from a file
+	Entry   int    // 1-based position in File; 0 for problems with the file itself
+	Name    string
+	Message string
+	Hint    bool // probably a mistake, but the concept works as it is
+}
+
+func (p Problem) String() string {
+	var b strings.Builder
+	if p.File != "" {
+		b.WriteString(p.File + ": ")
+	}
+	if p.Entry > 0 {
+		fmt.Fprintf(&b, "entry %d: ", p.Entry)
+	}
+	if p.Name != "" {
+		fmt.Fprintf(&b, "%q: ", p.Name)
+	}
+	if p.Hint {
+		b.WriteString("hint: ")
+	}
+	b.WriteString(p.Message)
+	return b.String()
+}
+
+// categories are the categories of the built-in library.
+var categories = []string{
+	"Aircraft", "Animal", "Artifact", "Astronomy", "Biology", "Celestial",
+	"City", "Clothing", "Country", "Culture", "Equipment", "Food", "Geology",
+	"Historical", "Industrial", "Military", "Natural Feature", "Object",
+	"Physics", "Spacecraft", "Sports", "Sports Venue", "Structure", "Vehicle",
+	"Watercraft", "Weather",
+}
+
+var isCategory = make(map[string]bool, len(categories))
+
+func init() {
+	for _, c := range categories {
+		isCategory[c] = true
+	}
+}
+
+// Categories returns the categories Validate accepts.
+func Categories() []string {
+	return append([]string(nil), categories...)
+}
+
+// fields are the JSON fields holding each dimension, for messages.
+var fields = map[string]string{
+	"length": "length_m", "height": "height_m", "width": "width_m",
+	"weight": "weight_kg", "volume": "volume_m3", "area": "area_m2",
+	"distance": "distance_m", "duration": "duration_s", "speed": "speed_mps",
+	"energy": "energy_j", "power": "power_w", "data": "data_bytes",
+	"money": "price_usd", "temperature": "temperature_k", "count": "count",
+}
+
+// plausibleMax bounds measurements by category; the "" entry applies to
+// every category. The bounds are generous and only catch values that are
+// wrong by an order of magnitude or a unit.
+var plausibleMax = map[string]map[string]float64{
+	"": {
+		"speed":    299792458, // the speed of light
+		"length":   8.8e26,    // the observable universe
+		"distance": 8.8e26,
+	},
+	"Animal":          {"height": 20, "length": 60, "width": 30, "weight": 2e5, "speed": 150, "temperature": 320},
+	"Clothing":        {"height": 5, "length": 10, "width": 5, "weight": 50},
+	"Food":            {"height": 5, "length": 10, "width": 5, "weight": 2000},
+	"Vehicle":         {"height": 20, "length": 1000, "weight": 1e7, "speed": 400},
+	"Aircraft":        {"height": 100, "length": 500, "weight": 1e6, "speed": 3000},
+	"Structure":       {"height": 1500},
+	"Natural Feature": {"height": 12000}, // Mariana Trench
+	"City":            {"area": 1e11},
+	"Country":         {"area": 2e13, "count": 2e9},
+}
+
+// properCategories hold only named, unique things, as in
+// tools/data/proper_nouns.json.
+var properCategories = map[string]bool{"Country": true, "City": true, "Celestial": true, "Artifact": true}
+
+// properNameRe matches names of unique geographic features.
+var properNameRe = regexp.MustCompile(`^(Mount|Mt\.?|Lake) |( River| Ocean| Sea| Desert| Falls| Canal)$`)
+
+// Validate checks concepts for problems that make them unusable or that are
+// probably mistakes: missing names or categories, measurements that are
+// not positive numbers, duplicate names, categories unknown to the built-in
+// library, and implausible values. Named things without proper_noun,
+// prices without as_of and names that differ only in case, which load as
+// separate concepts, are reported as hints.
+func Validate(concepts []Concept) []Problem {
+	var problems []Problem
+	seen := make(map[string]int, len(concepts))
+	seenFold := make(map[string]int, len(concepts))
+	for i, c := range concepts {
+		report := func(hint bool, format string, args ...any) {
+			problems = append(problems, Problem{Entry: i + 1, Name: c.Name, Message: fmt.Sprintf(format, args...), Hint: hint})
+		}
+		if msg := malformed(c); msg != "" {
+			report(false, "%s", msg)
+		}
+		if c.Name != "" {
+			// Names are compared exactly, as the loader does.
+			key := strings.ToLower(c.Name)
+			j, dup := seen[c.Name]
+			k, fold := seenFold[key]
+			switch {
+			case dup:
+				report(false, "duplicate of entry %d", j+1)
+			case fold:
+				report(true, "differs only in case from entry %d (%q)", k+1, concepts[k].Name)
+			}
+			if !dup {
+				seen[c.Name] = i
+			}
+			if !fold {
+				seenFold[key] = i
+			}
+		}
+		if c.Category != "" && !isCategory[c.Category] {
+			report(false, "unknown category %q", c.Category)
+		}
+		for _, dim := range dimensions {
+			v, ok := c.ValueFor(dim)
+			if !ok || !(v > 0) {
+				continue
+			}
+			if max, ok := maxFor(c.Category, dim); ok && v > max {
+				report(false, "%s %g is implausible for %s (at most %g)", fields[dim], v, c.Category, max)
+			}
+		}
+		if !c.ProperNoun && (properCategories[c.Category] || properNameRe.MatchString(c.Name)) {
+			report(true, "looks like a named thing; set \"proper_noun\": true")
+		}
+		if _, ok := c.ValueFor("money"); ok && c.AsOf == "" {
+			report(true, "price_usd has no as_of date; prices change over time")
+		}
+	}
+	return problems
+}
+
+// ValidateFiles validates each concept file at paths, and each .json file
+// in directories among them. A concept named in an earlier file is
+// reported as a hint, since it replaces that concept when loaded. Files
+// that cannot be parsed are reported as problems; only unreadable paths
+// are errors.
+func ValidateFiles(paths ...string) ([]Problem, error) {
+	files, err := expandConceptPaths(paths)
+	if err != nil {
+		return nil, err
+	}
+	var problems []Problem
+	firstFile := make(map[string]string)
+	for _, file := range files {
+		b, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("reading concepts: %w", err)
+		}
+		concepts, err := decodeConcepts(b)
+		if err != nil {
+			problems = append(problems, Problem{File: file, Message: err.Error()})
+			continue
+		}
+		for _, p := range Validate(concepts) {
+			p.File = file
+			problems = append(problems, p)
+		}
+		for i, c := range concepts {
+			if prev, ok := firstFile[c.Name]; ok && prev != file {
+				problems = append(problems, Problem{File: file, Entry: i + 1, Name: c.Name, Message: "replaces the concept in " + prev, Hint: true})
+			} else if !ok {
+				firstFile[c.Name] = file
+			}
+		}
+	}
+	return problems, nil
+}
+
+// Failed reports whether problems include any that are not hints.
+func Failed(problems []Problem) bool {
+	for _, p := range problems {
+		if !p.Hint {
+			return true
+		}
+	}
+	return false
+}
+
+func maxFor(category, dim string) (float64, bool) {
+	if max, ok := plausibleMax[category][dim]; ok {
+		return max, true
+	}
+	max, ok := plausibleMax[""][dim]
+	return max, ok
+}
+
+// ValidateLibrary validates the built-in library as loaded by LoadConcepts.
+func ValidateLibrary() ([]Problem, error) {
+	concepts, err := LoadConcepts()
+	if err != nil {
+		return nil, err
+	}
+	problems := Validate(concepts)
+	for i := range problems {
+		problems[i].Entry = 0 // positions in the merged library mean nothing
+	}
+	return problems, nil
+}
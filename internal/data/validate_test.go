@@ -0,0 +1,119 @@
+package data
+
+import (
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestValidateLibrary(t *testing.T) {
+	problems, err := ValidateLibrary()
+	if err != nil {
+		t.Fatalf("ValidateLibrary() error: %v", err)
+	}
+	for _, p := range problems {
+		t.Errorf("built-in library: %s", p)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	v := func(x float64) *float64 { return &x }
+	concepts := []Concept{
+		{Name: "Cheetah", Category: "Animal", SpeedMPS: v(30)},
+		{Name: "", Category: "Animal", WeightKg: v(1)},
+		{Name: "Widget", Category: "Object"},
+		{Name: "Sinkhole", Category: "Natural Feature", HeightM: v(-40)},
+		{Name: "Void", Category: "Object", WeightKg: v(math.NaN())},
+		{Name: "cheetah", Category: "Animal", SpeedMPS: v(31)},
+		{Name: "Pallet", Category: "Warehouse", WeightKg: v(25)},
+		{Name: "Giant Moose", Category: "Animal", HeightM: v(9000)},
+		{Name: "Warp Drive", Category: "Vehicle", SpeedMPS: v(4e8)},
+		{Name: "Lake Tahoe", Category: "Natural Feature", AreaM2: v(4.9e8)},
+		{Name: "Atlantis", Category: "City", AreaM2: v(1e8)},
+		{Name: "Mars", Category: "Celestial", WeightKg: v(6.39e23)},
+		{Name: "Cheetah", Category: "Animal", SpeedMPS: v(29)},
+		{Name: "Zebra", Category: "Animal", WeightKg: v(350)},
+		{Name: "Big Mac", Category: "Food", PriceUSD: v(5.69)},
+		{Name: "Movie Ticket", Category: "Culture", PriceUSD: v(11), AsOf: "2023"},
+	}
+	want := []string{
+		`entry 2: missing name`,
+		`entry 3: "Widget": no measurements`,
+		`entry 4: "Sinkhole": height_m must be positive, got -40`,
+		`entry 5: "Void": weight_kg must be positive, got NaN`,
+		`entry 6: "cheetah": hint: differs only in case from entry 1 ("Cheetah")`,
+		`entry 7: "Pallet": unknown category "Warehouse"`,
+		`entry 8: "Giant Moose": height_m 9000 is implausible for Animal (at most 20)`,
+		`entry 9: "Warp Drive": speed_mps 4e+08 is implausible for Vehicle (at most 400)`,
+		`entry 10: "Lake Tahoe": hint: looks like a named thing`,
+		`entry 11: "Atlantis": hint: looks like a named thing`,
+		`entry 12: "Mars": hint: looks like a named thing`,
+		`entry 13: "Cheetah": duplicate of entry 1`,
+		`entry 15: "Big Mac": hint: price_usd has no as_of date`,
+	}
+	var got []string
+	for _, p := range Validate(concepts) {
+		got = append(got, p.String())
+	}
+	all := strings.Join(got, "\n")
+	for _, w := range want {
+		if !strings.Contains(all, w) {
+			t.Errorf("Validate() problems missing %q; got:\n%s", w, all)
+		}
+	}
+	if len(got) != len(want) {
+		t.Errorf("Validate() returned %d problems, want %d:\n%s", len(got), len(want), all)
+	}
+}
+
+func TestValidateCategories(t *testing.T) {
+	one := 1.0
+	for _, category := range Categories() {
+		for _, p := range Validate([]Concept{{Name: "Thing", Category: category, Count: &one}}) {
+			if strings.Contains(p.Message, "unknown category") {
+				t.Errorf("Validate() rejected category %q", category)
+			}
+		}
+	}
+}
+
+func TestValidateFiles(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, body string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(body), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	good := write("a.json", `[{"name": "SKU 1001", "category": "Object", "weight_kg": 2.5}]`)
+	override := write("b.json", `[{"name": "SKU 1001", "category": "Object", "weight_kg": 3}]`)
+	broken := write("c.json", `[{"name": "SKU 1002", "category": "Object", "weight_lbs": 3}]`)
+
+	problems, err := ValidateFiles(good)
+	if err != nil || len(problems) != 0 {
+		t.Errorf("ValidateFiles(good) = %v, %v, want no problems", problems, err)
+	}
+
+	problems, err = ValidateFiles(good, override)
+	if err != nil {
+		t.Fatalf("ValidateFiles() error: %v", err)
+	}
+	if len(problems) != 1 || !problems[0].Hint || problems[0].File != override || Failed(problems) {
+		t.Errorf("ValidateFiles(good, override) = %v, want one hint about the replaced concept", problems)
+	}
+
+	problems, err = ValidateFiles(dir)
+	if err != nil {
+		t.Fatalf("ValidateFiles(dir) error: %v", err)
+	}
+	if !Failed(problems) || problems[len(problems)-1].File != broken || !strings.Contains(problems[len(problems)-1].Message, "weight_lbs") {
+		t.Errorf("ValidateFiles(dir) = %v, want a failure naming weight_lbs in %s", problems, broken)
+	}
+
+	if _, err := ValidateFiles(filepath.Join(dir, "missing.json")); err == nil {
+		t.Error("ValidateFiles() with a missing file should fail")
+	}
+}
@@ -3,71 +3,69 @@ package formatter
 import (
 	"fmt"
 	"math"
+	"strconv"
 	"strings"
 
 	"github.com/creimer/lnag/internal/matcher"
+	"github.com/creimer/lnag/internal/units"
 )
 
 func HumanizeRatio(ratio float64) string {
 	switch {
+	case math.Abs(ratio-0.01) < 0.0005:
+		return "a hundredth"
+	case math.Abs(ratio-0.1) < 0.005:
+		return "a tenth"
+	case math.Abs(ratio-0.2) < 0.01:
+		return "a fifth"
 	case math.Abs(ratio-0.25) < 0.01:
 		return "a quarter"
 	case math.Abs(ratio-0.5) < 0.01:
 		return "half"
 	case math.Abs(ratio-1.0) < 0.1:
 		return ""
+	case ratio >= 1e6:
+		// "3.2 million times", "1.2 × 10²¹ times"
+		return HumanizeNumber(ratio) + " times"
 	case ratio > 1 && ratio == math.Floor(ratio):
-		return fmt.Sprintf("%dx", int(ratio))
+		return HumanizeNumber(ratio) + "x"
 	case ratio > 1:
-		floor := int(math.Floor(ratio))
-		frac := ratio - float64(floor)
+		floor := math.Floor(ratio)
+		frac := ratio - floor
 		switch {
 		case frac < 0.4:
-			return fmt.Sprintf("more than %d times", floor)
+			return fmt.Sprintf("more than %s times", HumanizeNumber(floor))
 		case frac < 0.7:
-			return fmt.Sprintf("%d and a half times", floor)
+			return fmt.Sprintf("%s and a half times", HumanizeNumber(floor))
 		default:
-			return fmt.Sprintf("almost %d times", floor+1)
+			return fmt.Sprintf("almost %s times", HumanizeNumber(floor+1))
 		}
-	default:
+	case ratio >= 0.1:
 		return fmt.Sprintf("%.1fx", ratio)
+	case ratio < 1e-6:
+		// "1.5 × 10⁻⁹ times"
+		return HumanizeNumber(ratio) + " times"
+	default:
+		// Two significant figures, as in scientific notation: "0.034x".
+		return strconv.FormatFloat(roundSig(ratio, 2), 'f', -1, 64) + "x"
 	}
 }
 
-func HumanizeCount(count float64) string {
-	n := int(math.Round(count))
-	if n < 0 {
-		return fmt.Sprintf("-%s", HumanizeCount(float64(-n)))
-	}
-
-	s := fmt.Sprintf("%d", n)
-	if len(s) <= 3 {
-		return s
-	}
-
-	var parts []string
-	for len(s) > 3 {
-		parts = append([]string{s[len(s)-3:]}, parts...)
-		s = s[:len(s)-3]
-	}
-	parts = append([]string{s}, parts...)
-	return strings.Join(parts, ",")
-}
-
 // ApproxCount formats a ratio as an approximate count for display with plural nouns.
+// Fractions and ratios in the millions are left to HumanizeNumber.
 func ApproxCount(ratio float64) string {
-	if ratio == math.Floor(ratio) {
-		return HumanizeCount(ratio)
+	if ratio == math.Floor(ratio) || ratio < 1 || ratio >= 1e6 {
+		return HumanizeNumber(ratio)
 	}
 	floor := int(math.Floor(ratio))
 	frac := ratio - float64(floor)
 	switch {
 	case frac < 0.4:
-		return fmt.Sprintf("more than %s", HumanizeCount(float64(floor)))
+		return fmt.Sprintf("more than %s", HumanizeNumber(float64(floor)))
 	case frac < 0.7:
-		return fmt.Sprintf("%s and a half", HumanizeCount(float64(floor)))
+		return fmt.Sprintf("%s and a half", HumanizeNumber(float64(floor)))
 	default:
-		return fmt.Sprintf("almost %s", HumanizeCount(float64(floor+1)))
+		return fmt.Sprintf("almost %s", HumanizeNumber(float64(floor+1)))
 	}
 }
 
@@ -77,6 +75,35 @@ func isDirectional(s string) bool {
 	return strings.HasPrefix(s, "more than") || strings.HasPrefix(s, "almost")
 }
 
+// emphasisWords adjusts a sentence's qualifiers for the result's emphasis.
+// It returns the word placed before fractions ("about half", "only about
+// half") followed by about, ratioStr and countStr, which become
+// "a whopping 500x" and "a whopping 500" for an emphasized large multiple.
+func emphasisWords(e matcher.Emphasis, about, ratioStr, countStr string) (string, string, string, string) {
+	approx := "about "
+	switch e {
+	case matcher.EmphasisSmall:
+		approx = "only about "
+		if about != "" {
+			about = approx
+		}
+	case matcher.EmphasisLarge:
+		if ratioStr != "" && !isDirectional(ratioStr) && !isDirectional(countStr) {
+			return approx, "", "a whopping " + ratioStr, "a whopping " + countStr
+		}
+	}
+	return approx, about, ratioStr, countStr
+}
+
+// timesWord spells out a multiple from HumanizeRatio ("3x" → "3 times") for
+// comparisons such as "3 times as fast as".
+func timesWord(ratioStr string) string {
+	if n, ok := strings.CutSuffix(ratioStr, "x"); ok {
+		return n + " times"
+	}
+	return ratioStr
+}
+
 // article returns "a" or "an" for simple English usage.
 func article(name string) string {
 	if len(name) == 0 {
@@ -108,6 +135,8 @@ func dimensionNoun(dimension string) string {
 		return "distance"
 	case "duration":
 		return "duration"
+	case "money":
+		return "price"
 	default:
 		return dimension
 	}
@@ -115,12 +144,16 @@ func dimensionNoun(dimension string) string {
 
 // dimensionPreposition returns the preposition used between the dimension noun
 // and the concept name. Most dimensions use "of" ("the length of"),
-// but distance uses "to" ("the distance to").
+// but distance uses "to" ("the distance to") and data "in" ("the data in").
 func dimensionPreposition(dimension string) string {
-	if dimension == "distance" {
+	switch dimension {
+	case "distance":
 		return "to"
+	case "data":
+		return "in"
+	default:
+		return "of"
 	}
-	return "of"
 }
 
 // dimensionVerb returns the verb phrase for stacking/lining up in a dimension.
@@ -140,135 +173,271 @@ func dimensionVerb(dimension string) string {
 		return "would span"
 	case "duration":
 		return "would last"
+	case "energy":
+		return "would release"
+	case "power":
+		return "would draw"
+	case "data":
+		return "would hold"
+	case "money":
+		return "would cost"
 	default:
 		return "would equal"
 	}
 }
 
-// pluralize adds an "s" to simple names.
-func pluralize(name string) string {
-	return name + "s"
+// reachVerb returns the verb phrase for items in a dimension laid end to
+// end toward a distance.
+func reachVerb(dimension string) string {
+	switch dimension {
+	case "height":
+		return "stacked would reach"
+	case "width":
+		return "side by side would reach"
+	default:
+		return "lined up would reach"
+	}
+}
+
+// formatQuantity echoes an input value with its unit, putting a currency
+// symbol in front ("$3.2 million") and any other unit after ("500 m").
+// Values on a logarithmic scale keep their decimals: magnitude 9.5 is not 10.
+func formatQuantity(value float64, unit string) string {
+	if num, _, ok := units.SplitRate(unit); ok && units.IsCurrencySymbol(num) {
+		// "$5 million per year"
+		return formatQuantity(value, num) + strings.TrimPrefix(unit, num)
+	}
+	if units.IsCurrencySymbol(unit) {
+		if value < 0 {
+			return "-" + unit + HumanizeNumber(-value)
+		}
+		return unit + HumanizeNumber(value)
+	}
+	if unit == "" {
+		return HumanizeNumber(value)
+	}
+	if info, err := units.Resolve(unit); err == nil && info.Scale == units.Logarithmic {
+		return strconv.FormatFloat(value, 'f', -1, 64) + " " + unit
+	}
+	return HumanizeNumber(value) + " " + unit
 }
 
 // FormatUnitResult formats a unit-mode result.
 // Example: "500 m is about the length of 5 Soccer Fields."
 func FormatUnitResult(r matcher.UnitResult, inputValue float64, unit string) string {
+	return fmt.Sprintf("%s is %s.", formatQuantity(inputValue, unit), unitPhrase(r, unit))
+}
+
+// unitPhrase compares an amount in unit with r's concept, e.g. "about the
+// length of 5 Soccer Fields".
+func unitPhrase(r matcher.UnitResult, unit string) string {
 	ratioStr := HumanizeRatio(r.Ratio)
 	countStr := ApproxCount(r.Ratio)
 	dim := dimensionNoun(r.Dimension)
-	name := r.Concept.Name
-	proper := r.Concept.ProperNoun
-	inputStr := HumanizeCount(inputValue)
+	n := nounOf(r.Concept)
+	name, ref := n.one, n.ref()
+	proper := !n.counted()
 
 	about := "about "
 	if isDirectional(ratioStr) || isDirectional(countStr) {
 		about = ""
 	}
+	approx, about, ratioStr, countStr := emphasisWords(r.Emphasis, about, ratioStr, countStr)
 
 	switch r.Dimension {
+	case "temperature":
+		return temperaturePhrase(r, unit)
+	case "count":
+		what := "the number of " + name
+		if r.Concept.Category == "City" || r.Concept.Category == "Country" {
+			what = "the population of " + name
+		}
+		switch {
+		case ratioStr == "":
+			return fmt.Sprintf("about %s", what)
+		case r.Ratio < 1:
+			return fmt.Sprintf("%s%s %s", approx, ratioStr, what)
+		default:
+			return fmt.Sprintf("%s%s %s", about, timesWord(ratioStr), what)
+		}
 	case "duration":
 		switch {
 		case ratioStr == "" && proper:
-			return fmt.Sprintf("%s %s is about as long as the %s.", inputStr, unit, name)
+			return fmt.Sprintf("about as long as %s", ref)
 		case ratioStr == "":
-			return fmt.Sprintf("%s %s is about as long as 1 %s.", inputStr, unit, name)
-		case r.Ratio < 1 && proper:
-			return fmt.Sprintf("%s %s is about %s as long as the %s.", inputStr, unit, ratioStr, name)
+			return fmt.Sprintf("about as long as 1 %s", name)
 		case r.Ratio < 1:
-			return fmt.Sprintf("%s %s is about %s as long as %s %s.", inputStr, unit, ratioStr, article(name), name)
+			return fmt.Sprintf("%s%s as long as %s", approx, ratioStr, ref)
 		case proper:
-			return fmt.Sprintf("%s %s is %s%s as long as the %s.", inputStr, unit, about, ratioStr, name)
+			return fmt.Sprintf("%s%s as long as %s", about, ratioStr, ref)
 		default:
-			return fmt.Sprintf("%s %s is %sas long as %s %s.", inputStr, unit, about, countStr, pluralize(name))
+			return fmt.Sprintf("%sas long as %s %s", about, countStr, n.many)
 		}
-	case "distance":
-		target := name
-		if proper {
-			target = "the " + name
+	case "energy", "power":
+		what := "as much " + dim + " as"
+		switch {
+		case ratioStr == "":
+			return fmt.Sprintf("about %s %s", what, ref)
+		case r.Ratio < 1:
+			return fmt.Sprintf("%s%s %s %s", approx, timesWord(ratioStr), what, ref)
+		case proper:
+			return fmt.Sprintf("%s%s %s %s", about, timesWord(ratioStr), what, ref)
+		default:
+			return fmt.Sprintf("%s%s %s %s", about, what, countStr, n.many)
+		}
+	case "money":
+		switch {
+		case ratioStr == "" && proper:
+			return fmt.Sprintf("about the price of %s", ref)
+		case ratioStr == "":
+			return fmt.Sprintf("about enough to buy %s", ref)
+		case r.Ratio < 1:
+			return fmt.Sprintf("%s%s the price of %s", approx, ratioStr, ref)
+		case proper:
+			return fmt.Sprintf("%s%s the price of %s", about, timesWord(ratioStr), ref)
+		default:
+			return fmt.Sprintf("enough to buy %s%s %s", about, countStr, n.many)
 		}
+	case "speed":
 		switch {
 		case ratioStr == "":
-			return fmt.Sprintf("%s %s is about the distance to %s.", inputStr, unit, target)
+			return fmt.Sprintf("about as fast as %s", ref)
 		case r.Ratio < 1:
-			return fmt.Sprintf("%s %s is about %s the distance to %s.", inputStr, unit, ratioStr, target)
+			return fmt.Sprintf("%s%s as fast as %s", approx, timesWord(ratioStr), ref)
 		default:
-			return fmt.Sprintf("%s %s is %s%s the distance to %s.", inputStr, unit, about, ratioStr, target)
+			return fmt.Sprintf("%s%s as fast as %s", about, timesWord(ratioStr), ref)
+		}
+	case "distance":
+		target := n.named()
+		switch {
+		case ratioStr == "":
+			return fmt.Sprintf("about the distance to %s", target)
+		case r.Ratio < 1:
+			return fmt.Sprintf("%s%s the distance to %s", approx, ratioStr, target)
+		default:
+			return fmt.Sprintf("%s%s the distance to %s", about, ratioStr, target)
 		}
 	default:
 		prep := dimensionPreposition(r.Dimension)
 		switch {
 		case ratioStr == "" && proper:
-			return fmt.Sprintf("%s %s is about the %s %s the %s.", inputStr, unit, dim, prep, name)
+			return fmt.Sprintf("about the %s %s %s", dim, prep, ref)
 		case ratioStr == "":
-			return fmt.Sprintf("%s %s is about the %s %s 1 %s.", inputStr, unit, dim, prep, name)
-		case proper:
-			return fmt.Sprintf("%s %s is %s%s the %s %s the %s.", inputStr, unit, about, ratioStr, dim, prep, name)
+			return fmt.Sprintf("about the %s %s 1 %s", dim, prep, name)
 		case r.Ratio < 1:
-			return fmt.Sprintf("%s %s is about %s the %s %s %s %s.", inputStr, unit, ratioStr, dim, prep, article(name), name)
+			return fmt.Sprintf("%s%s the %s %s %s", approx, ratioStr, dim, prep, ref)
+		case proper:
+			return fmt.Sprintf("%s%s the %s %s %s", about, ratioStr, dim, prep, ref)
 		default:
-			return fmt.Sprintf("%s %s is %sthe %s %s %s %s.", inputStr, unit, about, dim, prep, countStr, pluralize(name))
+			return fmt.Sprintf("%sthe %s %s %s %s", about, dim, prep, countStr, n.many)
 		}
 	}
 }
 
+// temperaturePhrase compares a temperature by its difference from the
+// concept, in the input's own unit since ratios of temperatures mean nothing
+// to a reader: "about 130 °C hotter than a Lava Flow".
+func temperaturePhrase(r matcher.UnitResult, unit string) string {
+	target := nounOf(r.Concept).ref()
+	kelvin, _ := r.Concept.ValueFor("temperature")
+
+	diff, diffUnit := r.Difference, "K"
+	if info, err := units.Resolve(unit); err == nil && info.Dimension == "temperature" {
+		diff, diffUnit = info.Difference(r.Difference), unit
+	}
+
+	if math.Abs(diff) < 1 || math.Abs(r.Difference) < 0.02*kelvin {
+		adjective := "hot"
+		switch {
+		case kelvin < 288.15: // 15 °C
+			adjective = "cold"
+		case kelvin < 323.15: // 50 °C
+			adjective = "warm"
+		}
+		return fmt.Sprintf("about as %s as %s", adjective, target)
+	}
+	comparative := "hotter"
+	if diff < 0 {
+		comparative = "colder"
+	}
+	// Whole degrees to two significant figures: "about 100 °C", not 99.8.
+	step := math.Round(roundSig(math.Abs(diff), 2))
+	return fmt.Sprintf("about %s %s %s than %s", HumanizeNumber(step), diffUnit, comparative, target)
+}
+
 // FormatDimensionResult formats a dimension-mode result.
 // Example: "2,000 Watermelons would weigh about as much as 2 African Elephants."
 func FormatDimensionResult(r matcher.DimensionResult) string {
-	countStr := HumanizeCount(r.Count)
-	unitName := pluralize(r.UnitItem.Name)
-	targetName := r.TargetItem.Name
-	proper := r.TargetItem.ProperNoun
+	countStr := HumanizeNumber(r.Count)
+	unitItem, target := nounOf(r.UnitItem), nounOf(r.TargetItem)
+	unitName, ref := unitItem.many, target.ref()
+	proper := !target.counted()
 	verb := dimensionVerb(r.Dimension)
 	ratioStr := HumanizeRatio(r.Ratio)
 	ratioCount := ApproxCount(r.Ratio)
-	art := article(targetName)
-	if proper {
-		art = "the"
-	}
 
 	about := "about "
 	if isDirectional(ratioStr) || isDirectional(ratioCount) {
 		about = ""
 	}
+	approx, about, ratioStr, ratioCount := emphasisWords(r.Emphasis, about, ratioStr, ratioCount)
+
+	if r.TargetDimension == "distance" {
+		verb := reachVerb(r.Dimension)
+		targetRef := target.named()
+		switch {
+		case ratioStr == "":
+			return fmt.Sprintf("%s %s %s about the distance to %s.", countStr, unitName, verb, targetRef)
+		case r.Ratio < 1:
+			return fmt.Sprintf("%s %s %s %s%s the distance to %s.", countStr, unitName, verb, approx, ratioStr, targetRef)
+		default:
+			return fmt.Sprintf("%s %s %s %s%s the distance to %s.", countStr, unitName, verb, about, ratioStr, targetRef)
+		}
+	}
 
 	switch r.Dimension {
-	case "weight":
+	case "weight", "money":
 		switch {
 		case ratioStr == "":
-			return fmt.Sprintf("%s %s %s about as much as %s %s.", countStr, unitName, verb, art, targetName)
+			return fmt.Sprintf("%s %s %s about as much as %s.", countStr, unitName, verb, ref)
 		case r.Ratio < 1:
-			return fmt.Sprintf("%s %s %s about %s as much as %s %s.", countStr, unitName, verb, ratioStr, art, targetName)
+			return fmt.Sprintf("%s %s %s %s%s as much as %s.", countStr, unitName, verb, approx, ratioStr, ref)
 		case proper:
-			return fmt.Sprintf("%s %s %s %s%s as much as the %s.", countStr, unitName, verb, about, ratioStr, targetName)
+			return fmt.Sprintf("%s %s %s %s%s as much as %s.", countStr, unitName, verb, about, ratioStr, ref)
 		default:
-			return fmt.Sprintf("%s %s %s %sas much as %s %s.", countStr, unitName, verb, about, ratioCount, pluralize(targetName))
+			return fmt.Sprintf("%s %s %s %sas much as %s %s.", countStr, unitName, verb, about, ratioCount, target.many)
+		}
+	case "energy", "power":
+		what := "as much " + dimensionNoun(r.Dimension) + " as"
+		switch {
+		case ratioStr == "":
+			return fmt.Sprintf("%s %s %s about %s %s.", countStr, unitName, verb, what, ref)
+		case r.Ratio < 1:
+			return fmt.Sprintf("%s %s %s %s%s %s %s.", countStr, unitName, verb, approx, timesWord(ratioStr), what, ref)
+		case proper:
+			return fmt.Sprintf("%s %s %s %s%s %s %s.", countStr, unitName, verb, about, timesWord(ratioStr), what, ref)
+		default:
+			return fmt.Sprintf("%s %s %s %s%s %s %s.", countStr, unitName, verb, about, what, ratioCount, target.many)
 		}
 	case "duration":
 		switch {
 		case ratioStr == "":
-			return fmt.Sprintf("%s %s %s about as long as %s %s.", countStr, unitName, verb, art, targetName)
+			return fmt.Sprintf("%s %s %s about as long as %s.", countStr, unitName, verb, ref)
 		case r.Ratio < 1:
-			return fmt.Sprintf("%s %s %s about %s as long as %s %s.", countStr, unitName, verb, ratioStr, art, targetName)
+			return fmt.Sprintf("%s %s %s %s%s as long as %s.", countStr, unitName, verb, approx, ratioStr, ref)
 		case proper:
-			return fmt.Sprintf("%s %s %s %s%s as long as the %s.", countStr, unitName, verb, about, ratioStr, targetName)
+			return fmt.Sprintf("%s %s %s %s%s as long as %s.", countStr, unitName, verb, about, ratioStr, ref)
 		default:
-			return fmt.Sprintf("%s %s %s %sas long as %s %s.", countStr, unitName, verb, about, ratioCount, pluralize(targetName))
+			return fmt.Sprintf("%s %s %s %sas long as %s %s.", countStr, unitName, verb, about, ratioCount, target.many)
 		}
 	case "distance":
-		unitTarget := r.UnitItem.Name
-		if r.UnitItem.ProperNoun {
-			unitTarget = "the " + r.UnitItem.Name
-		}
-		targetRef := r.TargetItem.Name
-		if proper {
-			targetRef = "the " + r.TargetItem.Name
-		}
-		unitPhrase := fmt.Sprintf("%sx the distance to %s", countStr, unitTarget)
+		targetRef := target.named()
+		unitPhrase := fmt.Sprintf("%sx the distance to %s", countStr, unitItem.named())
 		switch {
 		case ratioStr == "":
 			return fmt.Sprintf("%s %s about the distance to %s.", unitPhrase, verb, targetRef)
 		case r.Ratio < 1:
-			return fmt.Sprintf("%s %s about %s the distance to %s.", unitPhrase, verb, ratioStr, targetRef)
+			return fmt.Sprintf("%s %s %s%s the distance to %s.", unitPhrase, verb, approx, ratioStr, targetRef)
 		default:
 			return fmt.Sprintf("%s %s %s%s the distance to %s.", unitPhrase, verb, about, ratioStr, targetRef)
 		}
@@ -277,13 +446,66 @@ func FormatDimensionResult(r matcher.DimensionResult) string {
 		prep := dimensionPreposition(r.Dimension)
 		switch {
 		case ratioStr == "":
-			return fmt.Sprintf("%s %s %s about the %s %s %s %s.", countStr, unitName, verb, dim, prep, art, targetName)
-		case proper:
-			return fmt.Sprintf("%s %s %s %s%s the %s %s the %s.", countStr, unitName, verb, about, ratioStr, dim, prep, targetName)
+			return fmt.Sprintf("%s %s %s about the %s %s %s.", countStr, unitName, verb, dim, prep, ref)
 		case r.Ratio < 1:
-			return fmt.Sprintf("%s %s %s about %s the %s %s %s %s.", countStr, unitName, verb, ratioStr, dim, prep, article(targetName), targetName)
+			return fmt.Sprintf("%s %s %s %s%s the %s %s %s.", countStr, unitName, verb, approx, ratioStr, dim, prep, ref)
 		default:
-			return fmt.Sprintf("%s %s %s %s%s the %s %s %s %s.", countStr, unitName, verb, about, ratioStr, dim, prep, article(targetName), targetName)
+			return fmt.Sprintf("%s %s %s %s%s the %s %s %s.", countStr, unitName, verb, about, ratioStr, dim, prep, ref)
+		}
+	}
+}
+
+// FormatTimeResult formats a time-derived result: how long a speed takes to
+// cover a concept, a power takes to release a concept's energy, or an amount
+// of energy lasts at a concept's power.
+// Example: "60 km/s would reach the Moon in about 2 hours."
+func FormatTimeResult(r matcher.TimeResult, inputValue float64, unit string) string {
+	input := formatQuantity(inputValue, unit)
+	n := nounOf(r.Concept)
+	target := n.ref()
+	in := HumanizeDuration(r.Value, r.Unit)
+
+	switch r.Dimension {
+	case "power":
+		return fmt.Sprintf("%s would release as much energy as %s in %s.", input, target, in)
+	case "energy":
+		return fmt.Sprintf("%s would power %s for %s.", input, target, in)
+	}
+
+	switch r.TargetDimension {
+	case "distance":
+		return fmt.Sprintf("%s would reach %s in %s.", input, n.named(), in)
+	case "width":
+		return fmt.Sprintf("%s would cross %s in %s.", input, target, in)
+	default:
+		return fmt.Sprintf("%s would travel the %s of %s in %s.", input, dimensionNoun(r.TargetDimension), target, in)
+	}
+}
+
+// FormatRateResult formats a rate scaled to a window of time.
+// Example: "40 tons per day adds up to about the weight of 2 Blue Whales every week."
+func FormatRateResult(r matcher.RateResult, inputValue float64, unit string) string {
+	u := matcher.UnitResult{Concept: r.Concept, Ratio: r.Ratio, Dimension: r.Dimension, Emphasis: r.Emphasis}
+	window := "every " + r.Window
+	if c := r.WindowConcept; c != nil {
+		window = "during " + nounOf(*c).ref()
+	}
+	return fmt.Sprintf("%s adds up to %s %s.", formatQuantity(inputValue, unit), unitPhrase(u, unit), window)
+}
+
+// HumanizeDuration formats value of a time unit such as "hour", e.g.
+// "about 2 hours", "2 and a half days" or "almost 3 minutes".
+func HumanizeDuration(value float64, unit string) string {
+	rounded := math.Round(value)
+	if math.Abs(value-rounded) < 0.1 {
+		if rounded == 1 {
+			return "about 1 " + unit
 		}
+		return fmt.Sprintf("about %s %ss", HumanizeNumber(rounded), unit)
+	}
+	approx := ApproxCount(value)
+	if strings.HasSuffix(approx, " 1") {
+		return approx + " " + unit
 	}
+	return fmt.Sprintf("%s %ss", approx, unit)
 }
@@ -1,6 +1,7 @@
 package formatter
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/creimer/lnag/internal/data"
@@ -31,6 +32,13 @@ func TestHumanizeRatio(t *testing.T) {
 		{2.7, "almost 3 times"},
 		{5.8, "almost 6 times"},
 		{5.9, "almost 6 times"},
+		// Large ratios use HumanizeNumber
+		{1500, "1,500x"},
+		{1010.2, "more than 1,010 times"},
+		{999999.8, "almost 1 million times"},
+		{1e6, "1 million times"},
+		{3.2e9, "3.2 billion times"},
+		{1e21, "1 × 10²¹ times"},
 	}
 
 	for _, tt := range tests {
@@ -61,6 +69,8 @@ func TestApproxCount(t *testing.T) {
 		{".8 decimal", 5.8, "almost 6"},
 		{".9 decimal", 5.9, "almost 6"},
 		{"large approx", 1000.5, "1,000 and a half"},
+		{"fraction", 0.3, "0.3"},
+		{"millions", 12345678.9, "12.3 million"},
 	}
 
 	for _, tt := range tests {
@@ -73,27 +83,6 @@ func TestApproxCount(t *testing.T) {
 	}
 }
 
-func TestHumanizeCount(t *testing.T) {
-	tests := []struct {
-		count float64
-		want  string
-	}{
-		{1000, "1,000"},
-		{1234567, "1,234,567"},
-		{42, "42"},
-		{3.7, "4"},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.want, func(t *testing.T) {
-			got := HumanizeCount(tt.count)
-			if got != tt.want {
-				t.Errorf("HumanizeCount(%f) = %q, want %q", tt.count, got, tt.want)
-			}
-		})
-	}
-}
-
 func pf(v float64) *float64 { return &v }
 
 func TestFormatUnitResult(t *testing.T) {
@@ -136,6 +125,23 @@ func TestFormatUnitResult(t *testing.T) {
 		}
 	})
 
+	t.Run("small input keeps its digits", func(t *testing.T) {
+		r := matcher.UnitResult{
+			Concept:   data.Concept{Name: "Grain of Rice", LengthM: pf(0.006)},
+			Ratio:     0.5,
+			Dimension: "length",
+		}
+		if got := FormatUnitResult(r, 0.003, "km"); !strings.HasPrefix(got, "0.003 km is ") {
+			t.Errorf("got %q, want the input echoed as 0.003 km", got)
+		}
+		if got := FormatUnitResult(r, 3.10685596, "mi"); !strings.HasPrefix(got, "3.11 mi is ") {
+			t.Errorf("got %q, want the input echoed as 3.11 mi", got)
+		}
+		if got := FormatUnitResult(r, 0.4, "m"); !strings.HasPrefix(got, "0.4 m is ") {
+			t.Errorf("got %q, want the input echoed as 0.4 m", got)
+		}
+	})
+
 	t.Run("fractional ratio", func(t *testing.T) {
 		r := matcher.UnitResult{
 			Concept:   data.Concept{Name: "Blue Whale", WeightKg: pf(150000)},
@@ -156,7 +162,7 @@ func TestFormatUnitResult(t *testing.T) {
 			Dimension: "duration",
 		}
 		got := FormatUnitResult(r, 380, "sec")
-		want := "380 sec is about as long as 2 Average song length (pop, 2020s)s."
+		want := "380 sec is about as long as 2 Average song lengths (pop, 2020s)."
 		if got != want {
 			t.Errorf("got %q, want %q", got, want)
 		}
@@ -260,7 +266,7 @@ func TestFormatUnitResult(t *testing.T) {
 			Dimension: "duration",
 		}
 		got := FormatUnitResult(r, 437, "sec")
-		want := "437 sec is as long as more than 2 Average song length (pop, 2020s)s."
+		want := "437 sec is as long as more than 2 Average song lengths (pop, 2020s)."
 		if got != want {
 			t.Errorf("got %q, want %q", got, want)
 		}
@@ -273,7 +279,7 @@ func TestFormatUnitResult(t *testing.T) {
 			Dimension: "distance",
 		}
 		got := FormatUnitResult(r, 1140000000, "km")
-		want := "1,140,000,000 km is about 5x the distance to Mars."
+		want := "1.14 billion km is about 5x the distance to Mars."
 		if got != want {
 			t.Errorf("got %q, want %q", got, want)
 		}
@@ -312,7 +318,7 @@ func TestFormatUnitResult(t *testing.T) {
 			Dimension: "distance",
 		}
 		got := FormatUnitResult(r, 748000000, "km")
-		want := "748,000,000 km is about 5x the distance to the Sun."
+		want := "748 million km is about 5x the distance to the Sun."
 		if got != want {
 			t.Errorf("got %q, want %q", got, want)
 		}
@@ -325,7 +331,7 @@ func TestFormatUnitResult(t *testing.T) {
 			Dimension: "distance",
 		}
 		got := FormatUnitResult(r, 74800000, "km")
-		want := "74,800,000 km is about half the distance to the Sun."
+		want := "74.8 million km is about half the distance to the Sun."
 		if got != want {
 			t.Errorf("got %q, want %q", got, want)
 		}
@@ -364,7 +370,7 @@ func TestFormatUnitResult(t *testing.T) {
 			Dimension: "duration",
 		}
 		got := FormatUnitResult(r, 1382400, "sec")
-		want := "1,382,400 sec is about 2x as long as the Apollo 11 total mission."
+		want := "1.38 million sec is about 2x as long as the Apollo 11 total mission."
 		if got != want {
 			t.Errorf("got %q, want %q", got, want)
 		}
@@ -413,6 +419,21 @@ func TestFormatDimensionResult(t *testing.T) {
 		}
 	})
 
+	t.Run("fractional count", func(t *testing.T) {
+		r := matcher.DimensionResult{
+			UnitItem:   data.Concept{Name: "Ant", WeightKg: pf(0.000003)},
+			TargetItem: data.Concept{Name: "Grain of Rice", WeightKg: pf(0.00003)},
+			Count:      0.4,
+			Ratio:      0.04,
+			Dimension:  "weight",
+		}
+		got := FormatDimensionResult(r)
+		want := "0.4 Ants would weigh about 0.04x as much as a Grain of Rice."
+		if got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
 	t.Run("height comparison", func(t *testing.T) {
 		r := matcher.DimensionResult{
 			UnitItem:   data.Concept{Name: "Human", HeightM: pf(1.7)},
@@ -497,7 +518,7 @@ func TestFormatDimensionResult(t *testing.T) {
 			Dimension:  "duration",
 		}
 		got := FormatDimensionResult(r)
-		want := "100 Average song length (pop, 2020s)s would last about as long as 2 men's Marathon world records."
+		want := "100 Average song lengths (pop, 2020s) would last about as long as 2 men's Marathon world records."
 		if got != want {
 			t.Errorf("got %q, want %q", got, want)
 		}
@@ -587,9 +608,490 @@ func TestFormatDimensionResult(t *testing.T) {
 			Dimension:  "duration",
 		}
 		got := FormatDimensionResult(r)
-		want := "10,000,000 Eye blinks would last about 2x as long as the Apollo 11 total mission."
+		want := "10 million Eye blinks would last about 2x as long as the Apollo 11 total mission."
+		if got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("height stacked toward a distance", func(t *testing.T) {
+		r := matcher.DimensionResult{
+			UnitItem:        data.Concept{Name: "iPhone", HeightM: pf(0.0078)},
+			TargetItem:      data.Concept{Name: "Moon", DistanceM: pf(384400000), ProperNoun: true},
+			Count:           25000000000,
+			Ratio:           0.5,
+			Dimension:       "height",
+			TargetDimension: "distance",
+		}
+		got := FormatDimensionResult(r)
+		want := "25 billion iPhones stacked would reach about half the distance to the Moon."
+		if got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("length lined up toward a distance", func(t *testing.T) {
+		r := matcher.DimensionResult{
+			UnitItem:        data.Concept{Name: "Blue Whale", LengthM: pf(30)},
+			TargetItem:      data.Concept{Name: "Mars", DistanceM: pf(227900000000)},
+			Count:           15000000000,
+			Ratio:           2.0,
+			Dimension:       "length",
+			TargetDimension: "distance",
+		}
+		got := FormatDimensionResult(r)
+		want := "15 billion Blue Whales lined up would reach about 2x the distance to Mars."
+		if got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+}
+
+func TestHumanizeRatioSmallFractions(t *testing.T) {
+	tests := []struct {
+		ratio float64
+		want  string
+	}{
+		{0.01, "a hundredth"},
+		{0.1, "a tenth"},
+		{0.2, "a fifth"},
+		{0.05, "0.05x"},
+		{0.012, "0.012x"},
+		{0.0004, "0.0004x"},
+		{0.0343, "0.034x"},
+		{0.0341, "0.034x"},
+		{0.0867, "0.087x"},
+		{0.01234, "0.012x"},
+		{0.0012345, "0.0012x"},
+		{9.87e-6, "0.0000099x"},
+		{1.5e-9, "1.5 × 10⁻⁹ times"},
+		{1e-21, "1 × 10⁻²¹ times"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := HumanizeRatio(tt.ratio); got != tt.want {
+				t.Errorf("HumanizeRatio(%f) = %q, want %q", tt.ratio, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatEmphasis(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{
+			"unit small",
+			FormatUnitResult(matcher.UnitResult{
+				Concept:   data.Concept{Name: "Blue Whale", LengthM: pf(30)},
+				Ratio:     0.1,
+				Dimension: "length",
+				Emphasis:  matcher.EmphasisSmall,
+			}, 3, "m"),
+			"3 m is only about a tenth the length of a Blue Whale.",
+		},
+		{
+			"unit small proper",
+			FormatUnitResult(matcher.UnitResult{
+				Concept:   data.Concept{Name: "Eiffel Tower", HeightM: pf(330), ProperNoun: true},
+				Ratio:     0.5,
+				Dimension: "height",
+				Emphasis:  matcher.EmphasisSmall,
+			}, 165, "m"),
+			"165 m is only about half the height of the Eiffel Tower.",
+		},
+		{
+			"unit large",
+			FormatUnitResult(matcher.UnitResult{
+				Concept:   data.Concept{Name: "Soccer Field", LengthM: pf(100)},
+				Ratio:     500,
+				Dimension: "length",
+				Emphasis:  matcher.EmphasisLarge,
+			}, 50000, "m"),
+			"50,000 m is the length of a whopping 500 Soccer Fields.",
+		},
+		{
+			"unit large proper",
+			FormatUnitResult(matcher.UnitResult{
+				Concept:   data.Concept{Name: "Eiffel Tower", HeightM: pf(330), ProperNoun: true},
+				Ratio:     100,
+				Dimension: "height",
+				Emphasis:  matcher.EmphasisLarge,
+			}, 33000, "m"),
+			"33,000 m is a whopping 100x the height of the Eiffel Tower.",
+		},
+		{
+			"unit large duration",
+			FormatUnitResult(matcher.UnitResult{
+				Concept:   data.Concept{Name: "Eye blink", DurationS: pf(0.15)},
+				Ratio:     20,
+				Dimension: "duration",
+				Emphasis:  matcher.EmphasisLarge,
+			}, 3, "sec"),
+			"3 sec is as long as a whopping 20 Eye blinks.",
+		},
+		{
+			"unit large directional keeps wording",
+			FormatUnitResult(matcher.UnitResult{
+				Concept:   data.Concept{Name: "Soccer Field", LengthM: pf(100)},
+				Ratio:     20.2,
+				Dimension: "length",
+				Emphasis:  matcher.EmphasisLarge,
+			}, 2020, "m"),
+			"2,020 m is the length of more than 20 Soccer Fields.",
+		},
+		{
+			"dimension small",
+			FormatDimensionResult(matcher.DimensionResult{
+				UnitItem:   data.Concept{Name: "Human", HeightM: pf(1.7)},
+				TargetItem: data.Concept{Name: "Eiffel Tower", HeightM: pf(330), ProperNoun: true},
+				Count:      100,
+				Ratio:      0.5,
+				Dimension:  "height",
+				Emphasis:   matcher.EmphasisSmall,
+			}),
+			"100 Humans stacked would be only about half the height of the Eiffel Tower.",
+		},
+		{
+			"dimension large weight",
+			FormatDimensionResult(matcher.DimensionResult{
+				UnitItem:   data.Concept{Name: "Watermelon", WeightKg: pf(5)},
+				TargetItem: data.Concept{Name: "African Elephant", WeightKg: pf(5000)},
+				Count:      50000,
+				Ratio:      50,
+				Dimension:  "weight",
+				Emphasis:   matcher.EmphasisLarge,
+			}),
+			"50,000 Watermelons would weigh as much as a whopping 50 African Elephants.",
+		},
+		{
+			"dimension large length",
+			FormatDimensionResult(matcher.DimensionResult{
+				UnitItem:   data.Concept{Name: "Car", LengthM: pf(4.5)},
+				TargetItem: data.Concept{Name: "Soccer Field", LengthM: pf(100)},
+				Count:      1111,
+				Ratio:      50,
+				Dimension:  "length",
+				Emphasis:   matcher.EmphasisLarge,
+			}),
+			"1,111 Cars lined up would stretch a whopping 50x the length of a Soccer Field.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatSpeed(t *testing.T) {
+	tests := []struct {
+		name string
+		r    matcher.UnitResult
+		want string
+	}{
+		{
+			"multiple",
+			matcher.UnitResult{Concept: data.Concept{Name: "Rifle Bullet", SpeedMPS: pf(900)}, Ratio: 3, Dimension: "speed"},
+			"2,700 m/s is about 3 times as fast as a Rifle Bullet.",
+		},
+		{
+			"proper noun fraction",
+			matcher.UnitResult{Concept: data.Concept{Name: "speed of sound", SpeedMPS: pf(343), ProperNoun: true}, Ratio: 0.5, Dimension: "speed"},
+			"2,700 m/s is about half as fast as the speed of sound.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatUnitResult(tt.r, 2700, "m/s"); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatTimeResult(t *testing.T) {
+	tests := []struct {
+		name string
+		r    matcher.TimeResult
+		want string
+	}{
+		{
+			"width",
+			matcher.TimeResult{Concept: data.Concept{Name: "Atlantic Ocean", ProperNoun: true}, Dimension: "speed", TargetDimension: "width", Value: 2, Unit: "hour"},
+			"600 m/s would cross the Atlantic Ocean in about 2 hours.",
+		},
+		{
+			"distance",
+			matcher.TimeResult{Concept: data.Concept{Name: "Moon", ProperNoun: true}, Dimension: "speed", TargetDimension: "distance", Value: 7.5, Unit: "day"},
+			"600 m/s would reach the Moon in 7 and a half days.",
+		},
+		{
+			"length",
+			matcher.TimeResult{Concept: data.Concept{Name: "Olympic Pool"}, Dimension: "speed", TargetDimension: "length", Value: 1.2, Unit: "minute"},
+			"600 m/s would travel the length of an Olympic Pool in more than 1 minute.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatTimeResult(tt.r, 600, "m/s"); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatEnergyAndPower(t *testing.T) {
+	t.Run("unit energy proper noun", func(t *testing.T) {
+		r := matcher.UnitResult{Concept: data.Concept{Name: "Hiroshima atomic bomb", ProperNoun: true}, Ratio: 2, Dimension: "energy"}
+		got := FormatUnitResult(r, 30, "kilotons")
+		want := "30 kilotons is about 2 times as much energy as the Hiroshima atomic bomb."
+		if got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("unit power count", func(t *testing.T) {
+		r := matcher.UnitResult{Concept: data.Concept{Name: "Microwave Oven"}, Ratio: 5, Dimension: "power"}
+		got := FormatUnitResult(r, 5, "kW")
+		want := "5 kW is about as much power as 5 Microwave Ovens."
+		if got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("dimension energy", func(t *testing.T) {
+		r := matcher.DimensionResult{
+			UnitItem:   data.Concept{Name: "Big Mac"},
+			TargetItem: data.Concept{Name: "Lightning Bolt"},
+			Count:      500,
+			Ratio:      1,
+			Dimension:  "energy",
+		}
+		got := FormatDimensionResult(r)
+		want := "500 Big Macs would release about as much energy as a Lightning Bolt."
+		if got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("energy would power", func(t *testing.T) {
+		r := matcher.TimeResult{Concept: data.Concept{Name: "A19 Light Bulb"}, Dimension: "energy", TargetDimension: "power", Value: 2, Unit: "year"}
+		got := FormatTimeResult(r, 1, "MWh")
+		want := "1 MWh would power an A19 Light Bulb for about 2 years."
+		if got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("power would release", func(t *testing.T) {
+		r := matcher.TimeResult{Concept: data.Concept{Name: "Hiroshima atomic bomb", ProperNoun: true}, Dimension: "power", TargetDimension: "energy", Value: 17.5, Unit: "hour"}
+		got := FormatTimeResult(r, 1, "GW")
+		want := "1 GW would release as much energy as the Hiroshima atomic bomb in 17 and a half hours."
 		if got != want {
 			t.Errorf("got %q, want %q", got, want)
 		}
 	})
 }
+
+func TestFormatData(t *testing.T) {
+	r := matcher.UnitResult{Concept: data.Concept{Name: "DVD"}, Ratio: 8000, Dimension: "data"}
+	got := FormatUnitResult(r, 40, "TB")
+	want := "40 TB is about the data in 8,000 DVDs."
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	d := matcher.DimensionResult{
+		UnitItem:   data.Concept{Name: "Floppy Disk"},
+		TargetItem: data.Concept{Name: "CD"},
+		Count:      500,
+		Ratio:      1,
+		Dimension:  "data",
+	}
+	got = FormatDimensionResult(d)
+	want = "500 Floppy Disks would hold about the data in a CD."
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestFormatMoney(t *testing.T) {
+	tests := []struct {
+		name  string
+		r     matcher.UnitResult
+		value float64
+		unit  string
+		want  string
+	}{
+		{
+			name:  "many",
+			r:     matcher.UnitResult{Concept: data.Concept{Name: "Median US House"}, Ratio: 16000, Dimension: "money"},
+			value: 6.72e9, unit: "$",
+			want: "$6.72 billion is enough to buy about 16,000 Median US Houses.",
+		},
+		{
+			name:  "about one",
+			r:     matcher.UnitResult{Concept: data.Concept{Name: "Movie Ticket"}, Ratio: 1, Dimension: "money"},
+			value: 11, unit: "USD",
+			want: "11 USD is about enough to buy a Movie Ticket.",
+		},
+		{
+			name:  "fraction",
+			r:     matcher.UnitResult{Concept: data.Concept{Name: "Tesla Model S"}, Ratio: 0.5, Dimension: "money"},
+			value: 37500, unit: "$",
+			want: "$37,500 is about half the price of a Tesla Model S.",
+		},
+		{
+			name:  "proper multiple",
+			r:     matcher.UnitResult{Concept: data.Concept{Name: "Burj Khalifa", ProperNoun: true}, Ratio: 2, Dimension: "money"},
+			value: 3e9, unit: "€",
+			want: "€3 billion is about 2 times the price of the Burj Khalifa.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatUnitResult(tt.r, tt.value, tt.unit); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	d := matcher.DimensionResult{
+		UnitItem:   data.Concept{Name: "Big Mac"},
+		TargetItem: data.Concept{Name: "F-16 Fighter Jet"},
+		Count:      11000000,
+		Ratio:      1,
+		Dimension:  "money",
+	}
+	got := FormatDimensionResult(d)
+	want := "11 million Big Macs would cost about as much as a F-16 Fighter Jet."
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestFormatTemperature(t *testing.T) {
+	tests := []struct {
+		name  string
+		r     matcher.UnitResult
+		value float64
+		unit  string
+		want  string
+	}{
+		{
+			name:  "as hot as",
+			r:     matcher.UnitResult{Concept: data.Concept{Name: "Pot of Boiling Water", TemperatureK: pf(373.15)}, Difference: 0, Dimension: "temperature"},
+			value: 100, unit: "°C",
+			want: "100 °C is about as hot as a Pot of Boiling Water.",
+		},
+		{
+			name:  "as cold as",
+			r:     matcher.UnitResult{Concept: data.Concept{Name: "Dewar of Liquid Nitrogen", TemperatureK: pf(77.36)}, Difference: -0.21, Dimension: "temperature"},
+			value: -196, unit: "°C",
+			want: "-196 °C is about as cold as a Dewar of Liquid Nitrogen.",
+		},
+		{
+			name:  "hotter than",
+			r:     matcher.UnitResult{Concept: data.Concept{Name: "Lava Flow", TemperatureK: pf(1443)}, Difference: 130.15, Dimension: "temperature"},
+			value: 1300, unit: "°C",
+			want: "1,300 °C is about 130 °C hotter than a Lava Flow.",
+		},
+		{
+			name:  "colder than, in fahrenheit",
+			r:     matcher.UnitResult{Concept: data.Concept{Name: "surface of Venus", ProperNoun: true, TemperatureK: pf(737)}, Difference: -100, Dimension: "temperature"},
+			value: 700, unit: "°F",
+			want: "700 °F is about 180 °F colder than the surface of Venus.",
+		},
+		{
+			name:  "rounded difference",
+			r:     matcher.UnitResult{Concept: data.Concept{Name: "Candle Flame", TemperatureK: pf(1673)}, Difference: -99.8, Dimension: "temperature"},
+			value: 1300, unit: "°C",
+			want: "1,300 °C is about 100 °C colder than a Candle Flame.",
+		},
+		{
+			name:  "small rounded difference",
+			r:     matcher.UnitResult{Concept: data.Concept{Name: "Cup of Coffee", TemperatureK: pf(343.15)}, Difference: 12.4, Dimension: "temperature"},
+			value: 82.4, unit: "°C",
+			want: "82.4 °C is about 12 °C hotter than a Cup of Coffee.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatUnitResult(tt.r, tt.value, tt.unit); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatLogarithmicInput(t *testing.T) {
+	r := matcher.UnitResult{Concept: data.Concept{Name: "Tsar Bomba", ProperNoun: true}, Ratio: 50, Dimension: "energy"}
+	got := FormatUnitResult(r, 9.5, "Mw")
+	want := "9.5 Mw is about 50 times as much energy as the Tsar Bomba."
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestFormatCount(t *testing.T) {
+	tests := []struct {
+		name string
+		r    matcher.UnitResult
+		want string
+	}{
+		{
+			name: "population",
+			r:    matcher.UnitResult{Concept: data.Concept{Name: "New York City", Category: "City", ProperNoun: true}, Ratio: 1, Dimension: "count"},
+			want: "8 million is about the population of New York City.",
+		},
+		{
+			name: "population fraction",
+			r:    matcher.UnitResult{Concept: data.Concept{Name: "Canada", Category: "Country", ProperNoun: true}, Ratio: 0.2, Dimension: "count"},
+			want: "8 million is about a fifth the population of Canada.",
+		},
+		{
+			name: "tally",
+			r:    matcher.UnitResult{Concept: data.Concept{Name: "hairs on a human head", Category: "Biology"}, Ratio: 80, Dimension: "count"},
+			want: "8 million is about 80 times the number of hairs on a human head.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatUnitResult(tt.r, 8000000, ""); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatRateResult(t *testing.T) {
+	r := matcher.RateResult{
+		Concept:   data.Concept{Name: "Olympic Swimming Pool"},
+		Dimension: "volume",
+		Ratio:     3,
+		Window:    "minute",
+	}
+	got := FormatRateResult(r, 125, "m3/s")
+	want := "125 m3/s adds up to about the volume of 3 Olympic Swimming Pools every minute."
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	r = matcher.RateResult{
+		Concept:       data.Concept{Name: "Big Mac"},
+		Dimension:     "money",
+		Ratio:         1,
+		Window:        "Titanic sinking",
+		WindowConcept: &data.Concept{Name: "Titanic sinking", ProperNoun: true},
+	}
+	got = FormatRateResult(r, 5000000, "$ per year")
+	want = "$5 million per year adds up to about enough to buy a Big Mac during the Titanic sinking."
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,250 @@
+package formatter
+
+import (
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/creimer/lnag/internal/data"
+)
+
+// noun is how sentences name a concept.
+type noun struct {
+	one, many string // "Cup of Coffee", "Cups of Coffee"
+	proper    bool   // a unique thing: "the Eiffel Tower"
+	bare      bool   // a proper noun without "the": "Mars", "Paris"
+	mass      bool   // not countable: "Sand", never "a Sand" or "2 Sands"
+}
+
+// nounOf names c by its singular_display and plural, falling back to its
+// name and the English plural of it.
+func nounOf(c data.Concept) noun {
+	n := noun{one: c.Name, many: c.Plural, proper: c.ProperNoun}
+	if c.SingularDisplay != "" {
+		n.one = c.SingularDisplay
+	}
+	n.mass = c.Countable != nil && !*c.Countable
+	n.bare = n.proper && bareName(c.Category, n.one)
+	if n.many == "" {
+		switch {
+		case n.mass, n.proper, c.Count != nil:
+			// Uncountable, unique, or a tally that already names many
+			// things: "Sand", "the Times Square", "trees on Earth". Names
+			// keep their spelling.
+			n.many = n.one
+		default:
+			n.many = pluralize(n.one)
+		}
+	}
+	return n
+}
+
+// planets are the celestial bodies named without "the"; "the Moon" and "the
+// Sun" keep it.
+var planets = map[string]bool{
+	"Mercury": true, "Venus": true, "Earth": true, "Mars": true, "Jupiter": true,
+	"Saturn": true, "Uranus": true, "Neptune": true, "Pluto": true,
+}
+
+// bareNames are other landmarks named without "the".
+var bareNames = map[string]bool{
+	"Antarctica": true, "Stonehenge": true, "K2": true, "Aconcagua": true,
+	"Mont Blanc": true, "Ben Nevis": true, "Snowdon": true, "Uluru": true,
+	"Krakatoa": true, "Big Ben": true, "Machu Picchu": true,
+	"Angkor Wat": true, "Chichen Itza": true, "Hagia Sophia": true,
+	"Westminster Abbey": true, "Buckingham Palace": true,
+	"Table Mountain": true, "Taipei 101": true, "Christ the Redeemer": true,
+}
+
+// bareHeads and barePrefixes mark names that go without "the" by their
+// shape: "Times Square", "Central Park", "Mount Everest", "Lake Baikal".
+var (
+	bareHeads    = []string{"Square", "Park"}
+	barePrefixes = []string{"Mount ", "Mt. ", "Lake ", "Hurricane "}
+)
+
+// bareName reports whether a proper noun goes without "the": countries,
+// cities and planets ("India", "Paris", "Mars", but "the United States"),
+// squares, parks, mountains and lakes ("Times Square", "Mount Everest"),
+// and names that start with an owner ("Nelson's Column").
+func bareName(category, name string) bool {
+	switch category {
+	case "Country", "City":
+		return !strings.HasPrefix(name, "United ")
+	case "Celestial":
+		return planets[name]
+	}
+	if i := strings.Index(name, " ("); i > 0 {
+		name = name[:i]
+	}
+	if bareNames[name] || slices.Contains(bareHeads, headWord(name)) {
+		return true
+	}
+	for _, p := range barePrefixes {
+		if strings.HasPrefix(name, p) {
+			return true
+		}
+	}
+	owner, _, ok := strings.Cut(name, "'s ")
+	if !ok || strings.Contains(owner, " ") {
+		return false
+	}
+	r, _ := utf8.DecodeRuneInString(owner)
+	return unicode.IsUpper(r)
+}
+
+// counted reports whether sentences may count copies of the concept ("5
+// Soccer Fields"); unique and uncountable things are compared by ratio
+// instead ("5x the length of the Eiffel Tower").
+func (n noun) counted() bool {
+	return !n.proper && !n.mass
+}
+
+// ref refers to a single concept: "the Eiffel Tower", "Mars", "a Soccer
+// Field" or "Sand".
+func (n noun) ref() string {
+	switch {
+	case n.proper && !n.bare:
+		return "the " + n.one
+	case n.proper, n.mass:
+		return n.one
+	}
+	return article(n.one) + " " + n.one
+}
+
+// named refers to the concept where a count or "the" may precede it:
+// "the Eiffel Tower", "Sand" or, for countable concepts, the bare name.
+func (n noun) named() string {
+	if n.counted() {
+		return n.one
+	}
+	return n.ref()
+}
+
+// irregularPlurals are plurals English does not form with "s" or "es".
+// Words that are their own plural map to themselves.
+var irregularPlurals = map[string]string{
+	"child": "children", "person": "people", "mouse": "mice", "louse": "lice",
+	"goose": "geese", "foot": "feet", "tooth": "teeth", "ox": "oxen",
+	"die": "dice", "cactus": "cacti", "fungus": "fungi", "nucleus": "nuclei",
+	"loaf": "loaves", "calf": "calves", "half": "halves", "thief": "thieves",
+	"elf": "elves", "scarf": "scarves", "wife": "wives", "life": "lives",
+	"potato": "potatoes", "tomato": "tomatoes", "hero": "heroes",
+	"echo": "echoes", "torpedo": "torpedoes", "volcano": "volcanoes",
+	"tornado": "tornadoes", "mosquito": "mosquitoes", "domino": "dominoes",
+	"buffalo": "buffaloes", "stomach": "stomachs", "epoch": "epochs",
+	"monarch": "monarchs", "quiz": "quizzes", "matrix": "matrices",
+	"index": "indices", "vertex": "vertices", "appendix": "appendices",
+	"gas": "gases", "atlas": "atlases", "canvas": "canvases",
+	"lens": "lenses", "iris": "irises", "chassis": "chassis",
+
+	"sheep": "sheep", "deer": "deer", "moose": "moose", "bison": "bison",
+	"salmon": "salmon", "trout": "trout", "cod": "cod", "swine": "swine",
+	"cattle": "cattle", "species": "species", "series": "series",
+	"offspring": "offspring", "headquarters": "headquarters",
+
+	// Already plural.
+	"scissors": "scissors", "jeans": "jeans", "glasses": "glasses",
+	"sunglasses": "sunglasses", "binoculars": "binoculars",
+	"gloves": "gloves", "pants": "pants", "trousers": "trousers",
+	"shorts": "shorts", "pliers": "pliers", "tongs": "tongs",
+	"headphones": "headphones",
+}
+
+// irregularEndings are irregular plurals that compounds share:
+// "Swordfish", "Bookshelves", "Firemen".
+var irregularEndings = []struct{ one, many string }{
+	{"fish", "fish"}, {"craft", "craft"}, {"shelf", "shelves"},
+	{"knife", "knives"}, {"leaf", "leaves"}, {"wolf", "wolves"},
+	{"mouse", "mice"}, {"goose", "geese"}, {"man", "men"},
+}
+
+// notMan are words ending in "man" that take "s" rather than becoming
+// "men".
+var notMan = map[string]bool{
+	"human": true, "german": true, "roman": true, "shaman": true,
+	"talisman": true, "caiman": true, "ottoman": true, "walkman": true,
+}
+
+// prepositions end the head noun of a name: "Cup of Coffee", "Time to
+// boil water", "Drive across USA".
+var prepositions = []string{" of ", " to ", " in ", " on ", " at ", " for ", " per ", " from ", " across ", " around ", " with "}
+
+// pluralize forms the English plural of a concept name by pluralizing its
+// head word: "Smartphones (iPhone 14)", "Cups of Coffee".
+func pluralize(name string) string {
+	pre, head, tail := splitHead(name)
+	return pre + pluralWord(head) + tail
+}
+
+// headWord returns the head noun of a concept name.
+func headWord(name string) string {
+	_, head, _ := splitHead(name)
+	return head
+}
+
+// splitHead splits a concept name around its head noun, the last word
+// before any parenthetical or preposition: "Smartphone (iPhone 14)", "Cup
+// of Coffee".
+func splitHead(name string) (pre, head, tail string) {
+	head = name
+	if i := strings.Index(head, " ("); i > 0 && strings.HasSuffix(head, ")") {
+		head, tail = head[:i], head[i:]
+	}
+	end := len(head)
+	for _, p := range prepositions {
+		if i := strings.Index(head, p); i > 0 && i < end {
+			end = i
+		}
+	}
+	head, tail = head[:end], head[end:]+tail
+	i := strings.LastIndexAny(head, " -") + 1
+	return head[:i], head[i:], tail
+}
+
+// pluralWord forms the plural of a single word, keeping its capitals.
+func pluralWord(w string) string {
+	lower := strings.ToLower(w)
+	switch {
+	case w == "", utf8.RuneCountInString(w) == 1:
+		return w // model letters: "Tesla Model S"
+	case w != lower && w == strings.ToUpper(w):
+		return w + "s" // acronyms and numbers: "ATMs", "747s"
+	}
+	if p, ok := irregularPlurals[lower]; ok {
+		return matchCase(w, p)
+	}
+	for _, e := range irregularEndings {
+		if strings.HasSuffix(lower, e.one) && !(e.one == "man" && notMan[lower]) {
+			if len(w) == len(e.one) {
+				return matchCase(w, e.many)
+			}
+			return w[:len(w)-len(e.one)] + e.many
+		}
+	}
+	switch {
+	case strings.HasSuffix(lower, "ss"), strings.HasSuffix(lower, "us"):
+		return w + "es" // "Glasses", "Buses"
+	case strings.HasSuffix(lower, "is"):
+		return w[:len(w)-2] + "es" // "Axes", "Crises"
+	case strings.HasSuffix(lower, "s"):
+		return w // already plural: "trees", "Piano keys"
+	case strings.HasSuffix(lower, "x"), strings.HasSuffix(lower, "z"),
+		strings.HasSuffix(lower, "ch"), strings.HasSuffix(lower, "sh"):
+		return w + "es"
+	case strings.HasSuffix(lower, "y") && len(lower) > 1 && !strings.ContainsRune("aeiou", rune(lower[len(lower)-2])):
+		return w[:len(w)-1] + "ies"
+	}
+	return w + "s"
+}
+
+// matchCase gives p the capital first letter of w, if it has one.
+func matchCase(w, p string) string {
+	r, _ := utf8.DecodeRuneInString(w)
+	if !unicode.IsUpper(r) {
+		return p
+	}
+	first, size := utf8.DecodeRuneInString(p)
+	return string(unicode.ToUpper(first)) + p[size:]
+}
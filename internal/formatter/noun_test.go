@@ -0,0 +1,241 @@
+package formatter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/creimer/lnag/internal/data"
+	"github.com/creimer/lnag/internal/matcher"
+)
+
+func TestPluralize(t *testing.T) {
+	tests := []struct{ name, want string }{
+		{"Soccer Field", "Soccer Fields"},
+		{"Hippopotamus", "Hippopotamuses"},
+		{"School Bus", "School Buses"},
+		{"Computer Mouse", "Computer Mice"},
+		{"Sheep", "Sheep"},
+		{"Swordfish", "Swordfish"},
+		{"Hovercraft", "Hovercraft"},
+		{"Grey Wolf", "Grey Wolves"},
+		{"standard Bookshelf", "standard Bookshelves"},
+		{"Snowman", "Snowmen"},
+		{"Walking Human", "Walking Humans"},
+		{"Butterfly", "Butterflies"},
+		{"Subway", "Subways"},
+		{"Red Fox", "Red Foxes"},
+		{"Park Bench", "Park Benches"},
+		{"Potato", "Potatoes"},
+		{"Piano", "Pianos"},
+		{"standard Scissors", "standard Scissors"},
+		{"ATM", "ATMs"},
+		{"Boeing 747", "Boeing 747s"},
+		{"Porta-Potty", "Porta-Potties"},
+		{"Cup of Coffee", "Cups of Coffee"},
+		{"Loaf of Bread", "Loaves of Bread"},
+		{"Time to fall asleep (average)", "Times to fall asleep (average)"},
+		{"Smartphone (iPhone 14)", "Smartphones (iPhone 14)"},
+		{"Tesla Model S", "Tesla Model S"},
+		{"Piano keys", "Piano keys"},
+		{"trees on Earth", "trees on Earth"},
+		{"Glass of Ice Water", "Glasses of Ice Water"},
+		{"Walrus", "Walruses"},
+		{"Axis", "Axes"},
+		{"Cuban Missile Crisis", "Cuban Missile Crises"},
+		{"Quiz", "Quizzes"},
+		{"Matrix", "Matrices"},
+		{"Mailbox", "Mailboxes"},
+	}
+	for _, tt := range tests {
+		if got := pluralize(tt.name); got != tt.want {
+			t.Errorf("pluralize(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestNounOfPlural(t *testing.T) {
+	tests := []struct {
+		concept data.Concept
+		want    string
+	}{
+		{data.Concept{Name: "Computer Mouse"}, "Computer Mice"},
+		{data.Concept{Name: "trees on Earth", Count: pf(3e12)}, "trees on Earth"},
+		{data.Concept{Name: "Sand", Countable: new(bool)}, "Sand"},
+		{data.Concept{Name: "Paris", Category: "City", ProperNoun: true}, "Paris"},
+		{data.Concept{Name: "Niagara Falls", ProperNoun: true}, "Niagara Falls"},
+		{data.Concept{Name: "Eiffel Tower", ProperNoun: true}, "Eiffel Tower"},
+		{data.Concept{Name: "Colosseum Rome", SingularDisplay: "Colosseum", ProperNoun: true}, "Colosseum"},
+		{data.Concept{Name: "Sunlight travel to Earth", Countable: new(bool)}, "Sunlight travel to Earth"},
+	}
+	for _, tt := range tests {
+		if got := nounOf(tt.concept).many; got != tt.want {
+			t.Errorf("nounOf(%q).many = %q, want %q", tt.concept.Name, got, tt.want)
+		}
+	}
+}
+
+func TestConceptDisplayNames(t *testing.T) {
+	no := false
+	tests := []struct {
+		name    string
+		concept data.Concept
+		ratio   float64
+		dim     string
+		want    string
+	}{
+		{
+			"singular display",
+			data.Concept{Name: "Human heartbeat (one beat)", SingularDisplay: "Human heartbeat", DurationS: pf(0.86)},
+			3, "duration",
+			"2.6 s is about as long as 3 Human heartbeats.",
+		},
+		{
+			"singular display with article",
+			data.Concept{Name: "Human heartbeat (one beat)", SingularDisplay: "Human heartbeat", DurationS: pf(0.86)},
+			0.5, "duration",
+			"2.6 s is about half as long as a Human heartbeat.",
+		},
+		{
+			"plural",
+			data.Concept{Name: "Moose", Plural: "Mooses", WeightKg: pf(450)},
+			2, "weight",
+			"2.6 s is about the weight of 2 Mooses.",
+		},
+		{
+			"not countable",
+			data.Concept{Name: "Sand", Countable: &no, WeightKg: pf(1600)},
+			2, "weight",
+			"2.6 s is about 2x the weight of Sand.",
+		},
+		{
+			"not countable at ratio 1",
+			data.Concept{Name: "Sand", Countable: &no, WeightKg: pf(1600)},
+			1, "weight",
+			"2.6 s is about the weight of Sand.",
+		},
+		{
+			"planet",
+			data.Concept{Name: "Mars", Category: "Celestial", ProperNoun: true, WeightKg: pf(6.39e23)},
+			2, "weight",
+			"2.6 s is about 2x the weight of Mars.",
+		},
+		{
+			"moon",
+			data.Concept{Name: "Moon", Category: "Celestial", ProperNoun: true, WeightKg: pf(7.342e22)},
+			1, "weight",
+			"2.6 s is about the weight of the Moon.",
+		},
+		{
+			"country",
+			data.Concept{Name: "India", Category: "Country", ProperNoun: true, AreaM2: pf(3.287e12)},
+			0.5, "area",
+			"2.6 s is about half the area of India.",
+		},
+		{
+			"united country",
+			data.Concept{Name: "United Kingdom", Category: "Country", ProperNoun: true, AreaM2: pf(2.4e11)},
+			0.5, "area",
+			"2.6 s is about half the area of the United Kingdom.",
+		},
+		{
+			"owner",
+			data.Concept{Name: "Nelson's Column", Category: "Structure", ProperNoun: true, HeightM: pf(52)},
+			0.5, "height",
+			"2.6 s is about half the height of Nelson's Column.",
+		},
+		{
+			"square",
+			data.Concept{Name: "Times Square", Category: "Structure", ProperNoun: true, AreaM2: pf(1.4e4)},
+			0.5, "area",
+			"2.6 s is about half the area of Times Square.",
+		},
+		{
+			"mountain",
+			data.Concept{Name: "Mount Cook (New Zealand)", Category: "Natural Feature", ProperNoun: true, HeightM: pf(3724)},
+			0.5, "height",
+			"2.6 s is about half the height of Mount Cook (New Zealand).",
+		},
+		{
+			"tally",
+			data.Concept{Name: "trees on Earth", Category: "Biology", Count: pf(3e12)},
+			2, "count",
+			"2.6 s is about 2 times the number of trees on Earth.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := matcher.UnitResult{Concept: tt.concept, Ratio: tt.ratio, Dimension: tt.dim}
+			if got := FormatUnitResult(r, 2.6, "s"); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	dims := []struct {
+		name string
+		r    matcher.DimensionResult
+		want string
+	}{
+		{
+			"planet target",
+			matcher.DimensionResult{
+				UnitItem:   data.Concept{Name: "Boxing Glove (pair)", SingularDisplay: "pair of Boxing Gloves", WeightKg: pf(0.4)},
+				TargetItem: data.Concept{Name: "Mars", Category: "Celestial", ProperNoun: true, WeightKg: pf(6.39e23)},
+				Count:      3e24, Ratio: 2, Dimension: "weight",
+			},
+			"3 × 10²⁴ pairs of Boxing Gloves would weigh about 2x as much as Mars.",
+		},
+		{
+			"planet unit",
+			matcher.DimensionResult{
+				UnitItem:   data.Concept{Name: "Venus", Category: "Celestial", ProperNoun: true, WeightKg: pf(4.867e24)},
+				TargetItem: data.Concept{Name: "Sun", Category: "Celestial", ProperNoun: true, WeightKg: pf(1.989e30)},
+				Count:      400000, Ratio: 1, Dimension: "weight",
+			},
+			"400,000 Venus would weigh about as much as the Sun.",
+		},
+		{
+			"mass unit",
+			matcher.DimensionResult{
+				UnitItem:   data.Concept{Name: "Sand", Countable: &no, WeightKg: pf(1600)},
+				TargetItem: data.Concept{Name: "African Elephant", WeightKg: pf(6000)},
+				Count:      4, Ratio: 1, Dimension: "weight",
+			},
+			"4 Sand would weigh about as much as an African Elephant.",
+		},
+	}
+	for _, tt := range dims {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := FormatDimensionResult(tt.r); got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLibraryProperNounsNotPluralized(t *testing.T) {
+	concepts, err := data.LoadConcepts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	target := data.Concept{Name: "Pacific Ocean", ProperNoun: true}
+	for _, c := range concepts {
+		n := nounOf(c)
+		if !n.proper || c.Plural != "" {
+			continue
+		}
+		plural := pluralize(n.one)
+		if plural == n.one {
+			continue
+		}
+		for _, dim := range data.Dimensions() {
+			if _, ok := c.ValueFor(dim); !ok {
+				continue
+			}
+			r := matcher.DimensionResult{UnitItem: c, TargetItem: target, Count: 3, Ratio: 1, Dimension: dim}
+			if got := FormatDimensionResult(r); strings.Contains(got, plural) {
+				t.Errorf("%q: %q pluralizes a proper noun", c.Name, got)
+			}
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package formatter
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// scaleWords name the powers of a thousand used for large numbers.
+var scaleWords = []struct {
+	value float64
+	word  string
+}{
+	{1e12, "trillion"},
+	{1e9, "billion"},
+	{1e6, "million"},
+}
+
+// sigFigs is how many significant figures HumanizeNumber keeps for numbers
+// it cannot show exactly.
+const sigFigs = 3
+
+// HumanizeNumber formats a number for a sentence, choosing by magnitude:
+//
+//	below 10⁻⁶        1.5 × 10⁻⁹
+//	10⁻⁶ up to 100    0.004, 3.7, 42.5 (three significant figures)
+//	100 up to 10⁶     1,235 (rounded to a whole number)
+//	10⁶ up to 10¹⁵    3.2 million, 45 billion
+//	10¹⁵ and above    1.2 × 10²¹
+func HumanizeNumber(v float64) string {
+	switch {
+	case v == 0 || math.IsNaN(v) || math.IsInf(v, 0):
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case v < 0:
+		return "-" + HumanizeNumber(-v)
+	}
+
+	if v < 100 {
+		r := roundSig(v, sigFigs)
+		if r >= 1e-6 && r < 100 {
+			return strconv.FormatFloat(r, 'f', -1, 64)
+		}
+		if r < 1e-6 {
+			return scientific(v)
+		}
+	}
+	if n := math.Round(v); n < 1e6 {
+		return groupThousands(n)
+	}
+	for i := len(scaleWords) - 1; i >= 0; i-- {
+		s := scaleWords[i]
+		m := roundSig(v/s.value, sigFigs)
+		if m < 1000 {
+			return strconv.FormatFloat(m, 'f', -1, 64) + " " + s.word
+		}
+	}
+	return scientific(v)
+}
+
+// roundSig rounds v to n significant figures.
+func roundSig(v float64, n int) float64 {
+	if v == 0 {
+		return 0
+	}
+	scale := math.Pow(10, float64(n-1)-math.Floor(math.Log10(math.Abs(v))))
+	return math.Round(v*scale) / scale
+}
+
+// groupThousands writes a whole number with commas: 1234567 → "1,234,567".
+func groupThousands(n float64) string {
+	s := strconv.FormatFloat(n, 'f', 0, 64)
+	var parts []string
+	for len(s) > 3 {
+		parts = append([]string{s[len(s)-3:]}, parts...)
+		s = s[:len(s)-3]
+	}
+	return strings.Join(append([]string{s}, parts...), ",")
+}
+
+// scientific writes v as a mantissa of two significant figures times a power
+// of ten: "1.5 × 10⁻⁹".
+func scientific(v float64) string {
+	exp := math.Floor(math.Log10(v))
+	m := roundSig(v/math.Pow(10, exp), 2)
+	if m >= 10 {
+		m /= 10
+		exp++
+	}
+	return strconv.FormatFloat(m, 'f', -1, 64) + " × 10" + superscript(int(exp))
+}
+
+var superscripts = strings.NewReplacer(
+	"-", "⁻", "0", "⁰", "1", "¹", "2", "²", "3", "³", "4", "⁴",
+	"5", "⁵", "6", "⁶", "7", "⁷", "8", "⁸", "9", "⁹",
+)
+
+func superscript(n int) string {
+	return superscripts.Replace(strconv.Itoa(n))
+}
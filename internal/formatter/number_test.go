@@ -0,0 +1,43 @@
+package formatter
+
+import "testing"
+
+func TestHumanizeNumber(t *testing.T) {
+	tests := []struct {
+		name string
+		v    float64
+		want string
+	}{
+		{"zero", 0, "0"},
+		{"nano", 1.5e-9, "1.5 × 10⁻⁹"},
+		{"femto", 2e-15, "2 × 10⁻¹⁵"},
+		{"micro", 4.2e-6, "0.0000042"},
+		{"milli", 0.003, "0.003"},
+		{"tenths", 0.4, "0.4"},
+		{"three figures below one", 0.123456, "0.123"},
+		{"ones", 3.7, "3.7"},
+		{"whole ones", 42, "42"},
+		{"tens", 12.345, "12.3"},
+		{"rounds up to hundreds", 99.97, "100"},
+		{"hundreds", 123.6, "124"},
+		{"thousands", 1000, "1,000"},
+		{"hundred thousands", 999999.4, "999,999"},
+		{"rounds up to a million", 999999.7, "1 million"},
+		{"millions", 3.2e6, "3.2 million"},
+		{"millions to three figures", 1234567, "1.23 million"},
+		{"billions", 45e9, "45 billion"},
+		{"trillions", 123.4e12, "123 trillion"},
+		{"rounds up to a trillion", 999.9e9, "1 trillion"},
+		{"quadrillions", 5e15, "5 × 10¹⁵"},
+		{"sextillions", 1.23e21, "1.2 × 10²¹"},
+		{"negative", -0.4, "-0.4"},
+		{"negative millions", -3.2e6, "-3.2 million"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := HumanizeNumber(tt.v); got != tt.want {
+				t.Errorf("HumanizeNumber(%g) = %q, want %q", tt.v, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package matcher
+
+import (
+	"fmt"
+	"math"
+)
+
+// Emphasis biases matching toward analogies that stress how small or how
+// large a number is.
+type Emphasis int
+
+const (
+	EmphasisNone Emphasis = iota
+	EmphasisSmall
+	EmphasisLarge
+)
+
+const (
+	// smallRatio is the ratio below which something is clearly a fraction of
+	// the concept rather than "about the same".
+	smallRatio = 0.8
+	// largeRatio is the smallest ratio that counts as a large multiple.
+	largeRatio = 10
+)
+
+// smallNiceNumbers are the fractions that read naturally when stressing how
+// small something is ("a tenth of", "a quarter of").
+var smallNiceNumbers = []float64{0.01, 0.1, 0.2, 0.25, 0.5}
+
+// ParseEmphasis parses "small", "large" or "" (no emphasis).
+func ParseEmphasis(s string) (Emphasis, error) {
+	switch s {
+	case "":
+		return EmphasisNone, nil
+	case "small":
+		return EmphasisSmall, nil
+	case "large":
+		return EmphasisLarge, nil
+	default:
+		return EmphasisNone, fmt.Errorf("unknown emphasis %q (want small or large)", s)
+	}
+}
+
+func (e Emphasis) String() string {
+	switch e {
+	case EmphasisSmall:
+		return "small"
+	case EmphasisLarge:
+		return "large"
+	default:
+		return ""
+	}
+}
+
+// WithEmphasis prefers clear fractions (EmphasisSmall) or large multiples
+// (EmphasisLarge). Analogies in the other direction are only returned when
+// nothing in the preferred direction is available.
+func WithEmphasis(e Emphasis) Option {
+	return func(o *options) {
+		o.emphasis = e
+	}
+}
+
+// matches reports whether ratio points in the emphasized direction.
+func (e Emphasis) matches(ratio float64) bool {
+	switch e {
+	case EmphasisSmall:
+		return ratio < smallRatio
+	case EmphasisLarge:
+		return ratio >= largeRatio
+	default:
+		return false
+	}
+}
+
+// niceNumbers returns the nice numbers to aim for under the emphasis.
+func (e Emphasis) niceNumbers() []float64 {
+	if e == EmphasisSmall {
+		return append(smallNiceNumbers[:len(smallNiceNumbers):len(smallNiceNumbers)], niceNumbers...)
+	}
+	return niceNumbers
+}
+
+// score is ScoreRatio against the emphasis' nice numbers.
+func (e Emphasis) score(ratio float64) float64 {
+	return scoreAgainst(ratio, e.niceNumbers())
+}
+
+// scoreAgainst returns how far ratio is from the nearest of nices, in
+// orders of magnitude.
+func scoreAgainst(ratio float64, nices []float64) float64 {
+	best := math.MaxFloat64
+	for _, n := range nices {
+		dist := math.Abs(math.Log10(ratio / n))
+		if dist < best {
+			best = dist
+		}
+	}
+	return best
+}
+
+// emphasize keeps only the candidates in the emphasized direction, if there
+// are any, and marks their results via mark.
+func emphasize[T any](candidates []candidate[T], e Emphasis, mark func(*T)) []candidate[T] {
+	if e == EmphasisNone {
+		return candidates
+	}
+	var preferred []candidate[T]
+	for _, c := range candidates {
+		if e.matches(c.ratio) {
+			mark(&c.result)
+			preferred = append(preferred, c)
+		}
+	}
+	if len(preferred) == 0 {
+		return candidates
+	}
+	return preferred
+}
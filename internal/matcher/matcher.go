@@ -3,7 +3,6 @@ package matcher
 import (
 	"fmt"
 	"math"
-	"math/rand/v2"
 
 	"github.com/creimer/lnag/internal/data"
 )
@@ -15,20 +14,19 @@ var niceNumbers = []float64{0.5, 1, 2, 3, 5, 10, 20, 50, 100, 500, 1000}
 // ScoreRatio returns how "far" a ratio is from the nearest nice number.
 // Lower is better.
 func ScoreRatio(ratio float64) float64 {
-	best := math.MaxFloat64
-	for _, n := range niceNumbers {
-		dist := math.Abs(math.Log10(ratio / n))
-		if dist < best {
-			best = dist
-		}
-	}
-	return best
+	return EmphasisNone.score(ratio)
 }
 
+// UnitResult is a single-concept analogy. Emphasis is set when the ratio
+// points in the direction requested with WithEmphasis. For interval
+// dimensions such as temperature, Ratio is unset and Difference is the input
+// minus the concept's value, in base units.
 type UnitResult struct {
-	Concept   data.Concept
-	Ratio     float64
-	Dimension string
+	Concept    data.Concept
+	Ratio      float64
+	Difference float64
+	Dimension  string
+	Emphasis   Emphasis
 }
 
 // compatibleDimensions returns the set of dimensions to search.
@@ -47,12 +45,31 @@ func compatibleDimensions(dimension string) []string {
 
 // FindUnitMatch finds the concept whose measurement in the given dimension
 // produces the nicest ratio with the input value.
-func FindUnitMatch(value float64, dimension string, store *data.ConceptStore) (UnitResult, error) {
-	type candidate struct {
-		result UnitResult
-		score  float64
+func FindUnitMatch(value float64, dimension string, store *data.ConceptStore, opts ...Option) (UnitResult, error) {
+	o := newOptions(opts)
+	candidates, err := unitCandidates(value, dimension, store, o)
+	if err != nil {
+		return UnitResult{}, err
 	}
-	var candidates []candidate
+	return pick(candidates, o), nil
+}
+
+// FindUnitMatches returns up to n distinct concepts, best first. If n <= 0 it
+// returns every distinct concept FindUnitMatch could have picked.
+func FindUnitMatches(value float64, dimension string, store *data.ConceptStore, n int, opts ...Option) ([]UnitResult, error) {
+	candidates, err := unitCandidates(value, dimension, store, newOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+	return top(candidates, n), nil
+}
+
+func unitCandidates(value float64, dimension string, store *data.ConceptStore, o options) ([]candidate[UnitResult], error) {
+	if data.Interval(dimension) {
+		return intervalCandidates(value, dimension, store)
+	}
+
+	var candidates []candidate[UnitResult]
 
 	for _, dim := range compatibleDimensions(dimension) {
 		idx, ok := store.ByDimension[dim]
@@ -64,67 +81,149 @@ func FindUnitMatch(value float64, dimension string, store *data.ConceptStore) (U
 			if ratio < 0.01 || ratio > 100000 {
 				continue
 			}
-			score := ScoreRatio(ratio)
-			candidates = append(candidates, candidate{
+			candidates = append(candidates, candidate[UnitResult]{
 				result: UnitResult{
 					Concept:   *e.Concept,
 					Ratio:     ratio,
 					Dimension: dim,
 				},
-				score: score,
+				ratio: ratio,
+				score: o.emphasis.score(ratio),
+				key:   e.Concept.Name,
 			})
 		}
 	}
 
 	if len(candidates) == 0 {
 		dims := compatibleDimensions(dimension)
-		return UnitResult{}, fmt.Errorf("no valid comparison found for %v", dims)
+		return nil, fmt.Errorf("no valid comparison found for %v", dims)
 	}
+	return emphasize(candidates, o.emphasis, func(r *UnitResult) { r.Emphasis = o.emphasis }), nil
+}
 
-	bestScore := math.MaxFloat64
-	for _, c := range candidates {
-		if c.score < bestScore {
-			bestScore = c.score
-		}
+// intervalCandidates scores concepts by how close they are to value, relative
+// to the larger of the two, so the nearest concept on the scale wins.
+// Emphasis does not apply: a difference is neither a fraction nor a multiple.
+func intervalCandidates(value float64, dimension string, store *data.ConceptStore) ([]candidate[UnitResult], error) {
+	idx, ok := store.ByDimension[dimension]
+	if !ok || len(idx.Entries) == 0 {
+		return nil, fmt.Errorf("no valid comparison found for [%s]", dimension)
 	}
 
-	var topCandidates []candidate
-	for _, c := range candidates {
-		if c.score <= bestScore+scoreThreshold {
-			topCandidates = append(topCandidates, c)
-		}
+	candidates := make([]candidate[UnitResult], 0, len(idx.Entries))
+	for _, e := range idx.Entries {
+		diff := value - e.Value
+		candidates = append(candidates, candidate[UnitResult]{
+			result: UnitResult{
+				Concept:    *e.Concept,
+				Difference: diff,
+				Dimension:  dimension,
+			},
+			score: math.Abs(diff) / math.Max(value, e.Value),
+			key:   e.Concept.Name,
+		})
 	}
-
-	pick := topCandidates[rand.IntN(len(topCandidates))]
-	return pick.result, nil
+	return candidates, nil
 }
 
+// DimensionResult is a count-of-one-concept analogy. TargetDimension is set
+// when the target is measured in a different dimension than the unit item
+// (e.g. iPhones stacked by height against the distance to the Moon).
+// Emphasis is set when the ratio points in the direction requested with
+// WithEmphasis.
 type DimensionResult struct {
-	UnitItem   data.Concept
-	TargetItem data.Concept
-	Count      float64
-	Ratio      float64
-	Dimension  string
+	UnitItem        data.Concept
+	TargetItem      data.Concept
+	Count           float64
+	Ratio           float64
+	Dimension       string
+	TargetDimension string
+	Emphasis        Emphasis
 }
 
 // FindDimensionMatch finds a (unitItem, targetItem) pair such that
 // count * unitItem.value / targetItem.value is close to a nice number.
 // Uses binary search per nice number for O(n·k·log n) complexity.
-func FindDimensionMatch(count float64, dimension string, store *data.ConceptStore) (DimensionResult, error) {
+func FindDimensionMatch(count float64, dimension string, store *data.ConceptStore, opts ...Option) (DimensionResult, error) {
+	o := newOptions(opts)
+	candidates, err := dimensionCandidates(count, dimension, store, o)
+	if err != nil {
+		return DimensionResult{}, err
+	}
+	return pick(candidates, o), nil
+}
+
+// FindDimensionMatches returns up to n distinct (unitItem, targetItem) pairs,
+// best first. If n <= 0 it returns every distinct pair FindDimensionMatch
+// could have picked.
+func FindDimensionMatches(count float64, dimension string, store *data.ConceptStore, n int, opts ...Option) ([]DimensionResult, error) {
+	candidates, err := dimensionCandidates(count, dimension, store, newOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+	return top(candidates, n), nil
+}
+
+// FindAnyDimensionMatch is FindDimensionMatch across every dimension in the
+// store. The chosen dimension is reported in the result.
+func FindAnyDimensionMatch(count float64, store *data.ConceptStore, opts ...Option) (DimensionResult, error) {
+	o := newOptions(opts)
+	candidates, err := anyDimensionCandidates(count, store, o)
+	if err != nil {
+		return DimensionResult{}, err
+	}
+	return pick(candidates, o), nil
+}
+
+// FindAnyDimensionMatches is FindDimensionMatches across every dimension in
+// the store.
+func FindAnyDimensionMatches(count float64, store *data.ConceptStore, n int, opts ...Option) ([]DimensionResult, error) {
+	candidates, err := anyDimensionCandidates(count, store, newOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+	return top(candidates, n), nil
+}
+
+func anyDimensionCandidates(count float64, store *data.ConceptStore, o options) ([]candidate[DimensionResult], error) {
+	var candidates []candidate[DimensionResult]
+	for _, dim := range data.Dimensions() {
+		c, err := rawDimensionCandidates(count, dim, store, o)
+		if err != nil {
+			continue
+		}
+		candidates = append(candidates, c...)
+	}
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no valid comparison found for %g in any dimension", count)
+	}
+	return emphasize(candidates, o.emphasis, func(r *DimensionResult) { r.Emphasis = o.emphasis }), nil
+}
+
+func dimensionCandidates(count float64, dimension string, store *data.ConceptStore, o options) ([]candidate[DimensionResult], error) {
+	candidates, err := rawDimensionCandidates(count, dimension, store, o)
+	if err != nil {
+		return nil, err
+	}
+	return emphasize(candidates, o.emphasis, func(r *DimensionResult) { r.Emphasis = o.emphasis }), nil
+}
+
+// rawDimensionCandidates scores every (unitItem, targetItem) pair in one
+// dimension, before emphasis is applied.
+func rawDimensionCandidates(count float64, dimension string, store *data.ConceptStore, o options) ([]candidate[DimensionResult], error) {
+	if !data.Additive(dimension) {
+		return nil, fmt.Errorf("dimension %q cannot be counted", dimension)
+	}
 	idx, ok := store.ByDimension[dimension]
 	if !ok || len(idx.Entries) < 2 {
-		return DimensionResult{}, fmt.Errorf("not enough concepts for dimension %q", dimension)
+		return nil, fmt.Errorf("not enough concepts for dimension %q", dimension)
 	}
 
-	type candidate struct {
-		result DimensionResult
-		score  float64
-	}
-	var candidates []candidate
+	var candidates []candidate[DimensionResult]
 
 	for _, unitEntry := range idx.Entries {
 		totalValue := count * unitEntry.Value
-		for _, nice := range niceNumbers {
+		for _, nice := range o.emphasis.niceNumbers() {
 			idealTarget := totalValue / nice
 			closest := idx.FindClosest(idealTarget)
 			if closest == nil || closest.Concept == unitEntry.Concept || unitEntry.Value >= closest.Value {
@@ -134,8 +233,7 @@ func FindDimensionMatch(count float64, dimension string, store *data.ConceptStor
 			if ratio < 0.01 || ratio > 100000 {
 				continue
 			}
-			score := ScoreRatio(ratio)
-			candidates = append(candidates, candidate{
+			candidates = append(candidates, candidate[DimensionResult]{
 				result: DimensionResult{
 					UnitItem:   *unitEntry.Concept,
 					TargetItem: *closest.Concept,
@@ -143,29 +241,101 @@ func FindDimensionMatch(count float64, dimension string, store *data.ConceptStor
 					Ratio:      ratio,
 					Dimension:  dimension,
 				},
-				score: score,
+				ratio: ratio,
+				score: o.emphasis.score(ratio),
+				key:   dimension + "\x00" + unitEntry.Concept.Name + "\x00" + closest.Concept.Name,
 			})
 		}
 	}
 
 	if len(candidates) == 0 {
-		return DimensionResult{}, fmt.Errorf("no valid comparison found for dimension %q", dimension)
+		return nil, fmt.Errorf("no valid comparison found for dimension %q", dimension)
 	}
+	return candidates, nil
+}
 
-	bestScore := math.MaxFloat64
-	for _, c := range candidates {
-		if c.score < bestScore {
-			bestScore = c.score
-		}
+// targetDimensions returns the dimensions a count of items measured in
+// dimension can be compared against. A stack or line of items can reach
+// a distance as well as another item's size.
+func targetDimensions(dimension string) []string {
+	switch dimension {
+	case "length", "height", "width":
+		return []string{dimension, "distance"}
+	default:
+		return []string{dimension}
 	}
+}
 
-	var topCandidates []candidate
-	for _, c := range candidates {
-		if c.score <= bestScore+scoreThreshold {
-			topCandidates = append(topCandidates, c)
+// FindItemMatch is FindDimensionMatch with the unit item fixed: it finds a
+// target such that count * item / target is close to a nice number, trying
+// each of the given dimensions, or every dimension item has if none are given.
+func FindItemMatch(count float64, item data.Concept, store *data.ConceptStore, dimensions []string, opts ...Option) (DimensionResult, error) {
+	o := newOptions(opts)
+	candidates, err := itemCandidates(count, item, store, dimensions, o)
+	if err != nil {
+		return DimensionResult{}, err
+	}
+	return pick(candidates, o), nil
+}
+
+// FindItemMatches returns up to n distinct targets for FindItemMatch, best
+// first. If n <= 0 it returns every target FindItemMatch could have picked.
+func FindItemMatches(count float64, item data.Concept, store *data.ConceptStore, dimensions []string, n int, opts ...Option) ([]DimensionResult, error) {
+	candidates, err := itemCandidates(count, item, store, dimensions, newOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+	return top(candidates, n), nil
+}
+
+func itemCandidates(count float64, item data.Concept, store *data.ConceptStore, dimensions []string, o options) ([]candidate[DimensionResult], error) {
+	if len(dimensions) == 0 {
+		dimensions = data.Dimensions()
+	}
+
+	var candidates []candidate[DimensionResult]
+	for _, dim := range dimensions {
+		itemValue, ok := item.ValueFor(dim)
+		if !ok || itemValue == 0 || !data.Additive(dim) {
+			continue
+		}
+		totalValue := count * itemValue
+		for _, targetDim := range targetDimensions(dim) {
+			idx, ok := store.ByDimension[targetDim]
+			if !ok {
+				continue
+			}
+			for _, nice := range o.emphasis.niceNumbers() {
+				closest := idx.FindClosest(totalValue / nice)
+				if closest == nil || closest.Concept.Name == item.Name || itemValue >= closest.Value {
+					continue
+				}
+				ratio := totalValue / closest.Value
+				if ratio < 0.01 || ratio > 100000 {
+					continue
+				}
+				result := DimensionResult{
+					UnitItem:   item,
+					TargetItem: *closest.Concept,
+					Count:      count,
+					Ratio:      ratio,
+					Dimension:  dim,
+				}
+				if targetDim != dim {
+					result.TargetDimension = targetDim
+				}
+				candidates = append(candidates, candidate[DimensionResult]{
+					result: result,
+					ratio:  ratio,
+					score:  o.emphasis.score(ratio),
+					key:    dim + "\x00" + targetDim + "\x00" + closest.Concept.Name,
+				})
+			}
 		}
 	}
 
-	pick := topCandidates[rand.IntN(len(topCandidates))]
-	return pick.result, nil
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no valid comparison found for %g %s", count, item.Name)
+	}
+	return emphasize(candidates, o.emphasis, func(r *DimensionResult) { r.Emphasis = o.emphasis }), nil
 }
@@ -1,6 +1,8 @@
 package matcher
 
 import (
+	"math"
+	"math/rand/v2"
 	"testing"
 
 	"github.com/creimer/lnag/internal/data"
@@ -37,7 +39,7 @@ func TestScoreRatioNiceNumbersBeatOddOnes(t *testing.T) {
 func pf(v float64) *float64 { return &v }
 
 func makeStore(concepts []data.Concept) *data.ConceptStore {
-	dims := []string{"length", "height", "width", "weight", "volume", "area", "duration"}
+	dims := []string{"length", "height", "width", "weight", "volume", "area", "distance", "duration", "speed", "energy", "power", "temperature"}
 	byDim := make(map[string]*data.DimensionIndex, len(dims))
 	for _, dim := range dims {
 		var entries []data.IndexEntry
@@ -204,3 +206,407 @@ func TestFindDimensionMatchDuration(t *testing.T) {
 		t.Error("target item should have duration")
 	}
 }
+
+func TestFindUnitMatchWithRand(t *testing.T) {
+	concepts := []data.Concept{
+		{Name: "Soccer Field", LengthM: pf(100)},
+		{Name: "Tennis Court", LengthM: pf(23.77)},
+		{Name: "Basketball Court", LengthM: pf(28)},
+		{Name: "Olympic Pool", LengthM: pf(50)},
+	}
+	store := makeStore(concepts)
+
+	first, err := FindUnitMatch(500, "length", store, WithRand(rand.New(rand.NewPCG(1, 2))))
+	if err != nil {
+		t.Fatalf("FindUnitMatch() error: %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		got, err := FindUnitMatch(500, "length", store, WithRand(rand.New(rand.NewPCG(1, 2))))
+		if err != nil {
+			t.Fatalf("FindUnitMatch() error: %v", err)
+		}
+		if got.Concept.Name != first.Concept.Name {
+			t.Fatalf("same seed gave %q then %q", first.Concept.Name, got.Concept.Name)
+		}
+	}
+}
+
+func TestFindUnitMatchBestOnly(t *testing.T) {
+	concepts := []data.Concept{
+		{Name: "Soccer Field", LengthM: pf(100)},
+		{Name: "Tennis Court", LengthM: pf(23.77)},
+		{Name: "Basketball Court", LengthM: pf(28)},
+		{Name: "Olympic Pool", LengthM: pf(50)},
+	}
+	store := makeStore(concepts)
+
+	for i := 0; i < 10; i++ {
+		result, err := FindUnitMatch(500, "length", store, BestOnly())
+		if err != nil {
+			t.Fatalf("FindUnitMatch() error: %v", err)
+		}
+		// 500/100 = 5 and 500/50 = 10 both score 0; the tie goes to the name
+		// that sorts first.
+		if result.Concept.Name != "Olympic Pool" {
+			t.Fatalf("BestOnly picked %q, want Olympic Pool", result.Concept.Name)
+		}
+	}
+}
+
+func TestFindDimensionMatchBestOnly(t *testing.T) {
+	concepts := []data.Concept{
+		{Name: "Watermelon", WeightKg: pf(5)},
+		{Name: "Bowling Ball", WeightKg: pf(7)},
+		{Name: "African Elephant", WeightKg: pf(5000)},
+		{Name: "Blue Whale", WeightKg: pf(150000)},
+	}
+	store := makeStore(concepts)
+
+	first, err := FindDimensionMatch(2000, "weight", store, BestOnly())
+	if err != nil {
+		t.Fatalf("FindDimensionMatch() error: %v", err)
+	}
+	if ScoreRatio(first.Ratio) != 0 {
+		t.Errorf("BestOnly ratio %f has score %f, want 0", first.Ratio, ScoreRatio(first.Ratio))
+	}
+	for i := 0; i < 10; i++ {
+		got, err := FindDimensionMatch(2000, "weight", store, BestOnly())
+		if err != nil {
+			t.Fatalf("FindDimensionMatch() error: %v", err)
+		}
+		if got.UnitItem.Name != first.UnitItem.Name || got.TargetItem.Name != first.TargetItem.Name {
+			t.Fatalf("BestOnly gave %q/%q then %q/%q", first.UnitItem.Name, first.TargetItem.Name,
+				got.UnitItem.Name, got.TargetItem.Name)
+		}
+	}
+}
+
+func TestFindUnitMatches(t *testing.T) {
+	concepts := []data.Concept{
+		{Name: "Soccer Field", LengthM: pf(100)},
+		{Name: "Tennis Court", LengthM: pf(23.77)},
+		{Name: "Basketball Court", LengthM: pf(28)},
+		{Name: "Olympic Pool", LengthM: pf(50)},
+		{Name: "Mars", DistanceM: pf(227900000000)},
+	}
+	store := makeStore(concepts)
+	store.ByDimension["distance"] = &data.DimensionIndex{
+		Entries: []data.IndexEntry{{Concept: &concepts[4], Value: 227900000000}},
+	}
+
+	results, err := FindUnitMatches(500, "length", store, 3)
+	if err != nil {
+		t.Fatalf("FindUnitMatches() error: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3", len(results))
+	}
+	seen := make(map[string]bool)
+	for i, r := range results {
+		if seen[r.Concept.Name] {
+			t.Errorf("duplicate concept %q", r.Concept.Name)
+		}
+		seen[r.Concept.Name] = true
+		if i > 0 && ScoreRatio(r.Ratio) < ScoreRatio(results[i-1].Ratio) {
+			t.Errorf("results not sorted by score at index %d", i)
+		}
+	}
+	if results[0].Concept.Name != "Olympic Pool" || results[1].Concept.Name != "Soccer Field" {
+		t.Errorf("top two = %q, %q, want Olympic Pool, Soccer Field", results[0].Concept.Name, results[1].Concept.Name)
+	}
+
+	all, err := FindUnitMatches(500, "length", store, 0)
+	if err != nil {
+		t.Fatalf("FindUnitMatches() error: %v", err)
+	}
+	for _, r := range all {
+		if ScoreRatio(r.Ratio) > scoreThreshold {
+			t.Errorf("n=0 returned %q with score %f above threshold", r.Concept.Name, ScoreRatio(r.Ratio))
+		}
+	}
+	if len(all) != 4 {
+		t.Errorf("n=0 returned %d results, want 4", len(all))
+	}
+}
+
+func TestFindDimensionMatches(t *testing.T) {
+	concepts := []data.Concept{
+		{Name: "Watermelon", WeightKg: pf(5)},
+		{Name: "Bowling Ball", WeightKg: pf(7)},
+		{Name: "Car", WeightKg: pf(1500)},
+		{Name: "African Elephant", WeightKg: pf(5000)},
+		{Name: "Blue Whale", WeightKg: pf(150000)},
+	}
+	store := makeStore(concepts)
+
+	results, err := FindDimensionMatches(2000, "weight", store, 5)
+	if err != nil {
+		t.Fatalf("FindDimensionMatches() error: %v", err)
+	}
+	if len(results) == 0 || len(results) > 5 {
+		t.Fatalf("got %d results, want 1-5", len(results))
+	}
+	seen := make(map[string]bool)
+	for i, r := range results {
+		key := r.UnitItem.Name + "/" + r.TargetItem.Name
+		if seen[key] {
+			t.Errorf("duplicate pair %q", key)
+		}
+		seen[key] = true
+		if i > 0 && ScoreRatio(r.Ratio) < ScoreRatio(results[i-1].Ratio) {
+			t.Errorf("results not sorted by score at index %d", i)
+		}
+	}
+}
+
+func TestParseEmphasis(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Emphasis
+		wantErr bool
+	}{
+		{"", EmphasisNone, false},
+		{"small", EmphasisSmall, false},
+		{"large", EmphasisLarge, false},
+		{"huge", EmphasisNone, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseEmphasis(tt.in)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("ParseEmphasis(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("ParseEmphasis(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestFindUnitMatchEmphasis(t *testing.T) {
+	concepts := []data.Concept{
+		{Name: "Ant", LengthM: pf(0.005)},
+		{Name: "Car", LengthM: pf(4.5)},
+		{Name: "Soccer Field", LengthM: pf(100)},
+		{Name: "Eiffel Tower", LengthM: pf(330)},
+		{Name: "Golden Gate Bridge", LengthM: pf(2737)},
+	}
+	store := makeStore(concepts)
+
+	for i := 0; i < 20; i++ {
+		small, err := FindUnitMatch(50, "length", store, WithEmphasis(EmphasisSmall))
+		if err != nil {
+			t.Fatalf("FindUnitMatch() error: %v", err)
+		}
+		if small.Ratio >= 1 {
+			t.Fatalf("small emphasis picked ratio %f (%s), want < 1", small.Ratio, small.Concept.Name)
+		}
+		if small.Emphasis != EmphasisSmall {
+			t.Errorf("small result Emphasis = %v, want small", small.Emphasis)
+		}
+
+		large, err := FindUnitMatch(50, "length", store, WithEmphasis(EmphasisLarge))
+		if err != nil {
+			t.Fatalf("FindUnitMatch() error: %v", err)
+		}
+		if large.Ratio < largeRatio {
+			t.Fatalf("large emphasis picked ratio %f (%s), want >= %d", large.Ratio, large.Concept.Name, largeRatio)
+		}
+	}
+}
+
+func TestFindUnitMatchEmphasisFallsBack(t *testing.T) {
+	concepts := []data.Concept{
+		{Name: "Soccer Field", LengthM: pf(100)},
+	}
+	store := makeStore(concepts)
+
+	result, err := FindUnitMatch(500, "length", store, WithEmphasis(EmphasisSmall))
+	if err != nil {
+		t.Fatalf("FindUnitMatch() error: %v", err)
+	}
+	if result.Ratio != 5 {
+		t.Errorf("ratio = %f, want 5", result.Ratio)
+	}
+	if result.Emphasis != EmphasisNone {
+		t.Errorf("fallback result Emphasis = %v, want none", result.Emphasis)
+	}
+}
+
+func TestFindDimensionMatchEmphasis(t *testing.T) {
+	concepts := []data.Concept{
+		{Name: "Watermelon", WeightKg: pf(5)},
+		{Name: "Car", WeightKg: pf(1500)},
+		{Name: "African Elephant", WeightKg: pf(5000)},
+		{Name: "Blue Whale", WeightKg: pf(150000)},
+	}
+	store := makeStore(concepts)
+
+	for i := 0; i < 20; i++ {
+		small, err := FindDimensionMatch(100, "weight", store, WithEmphasis(EmphasisSmall))
+		if err != nil {
+			t.Fatalf("FindDimensionMatch() error: %v", err)
+		}
+		if small.Ratio >= 1 || small.Emphasis != EmphasisSmall {
+			t.Fatalf("small emphasis gave ratio %f, emphasis %v", small.Ratio, small.Emphasis)
+		}
+
+		large, err := FindDimensionMatch(100, "weight", store, WithEmphasis(EmphasisLarge))
+		if err != nil {
+			t.Fatalf("FindDimensionMatch() error: %v", err)
+		}
+		if large.Ratio < largeRatio || large.Emphasis != EmphasisLarge {
+			t.Fatalf("large emphasis gave ratio %f, emphasis %v", large.Ratio, large.Emphasis)
+		}
+	}
+}
+
+func TestFindAnyDimensionMatch(t *testing.T) {
+	concepts := []data.Concept{
+		{Name: "Watermelon", WeightKg: pf(5)},
+		{Name: "African Elephant", WeightKg: pf(5000)},
+		{Name: "Eye blink", DurationS: pf(0.15)},
+		{Name: "Average song length (pop, 2020s)", DurationS: pf(190)},
+		{Name: "Soccer Field", LengthM: pf(100)},
+	}
+	store := makeStore(concepts)
+
+	for i := 0; i < 50; i++ {
+		result, err := FindAnyDimensionMatch(2000, store)
+		if err != nil {
+			t.Fatalf("FindAnyDimensionMatch() error: %v", err)
+		}
+		if result.Dimension != "weight" && result.Dimension != "duration" {
+			t.Errorf("dimension = %q, want weight or duration", result.Dimension)
+		}
+		if ScoreRatio(result.Ratio) > scoreThreshold+0.01 {
+			t.Errorf("ratio %f has poor score %f", result.Ratio, ScoreRatio(result.Ratio))
+		}
+	}
+
+	best, err := FindAnyDimensionMatch(2000, store, BestOnly())
+	if err != nil {
+		t.Fatalf("FindAnyDimensionMatch() error: %v", err)
+	}
+	// 2000 watermelons weigh exactly 2 elephants, a perfect score.
+	if best.Dimension != "weight" || best.Ratio != 2 {
+		t.Errorf("best = %s ratio %f, want weight ratio 2", best.Dimension, best.Ratio)
+	}
+}
+
+func TestFindAnyDimensionMatchNoConcepts(t *testing.T) {
+	store := makeStore([]data.Concept{{Name: "Soccer Field", LengthM: pf(100)}})
+	if _, err := FindAnyDimensionMatch(100, store); err == nil {
+		t.Error("expected error when no dimension has two concepts")
+	}
+}
+
+func TestFindItemMatch(t *testing.T) {
+	concepts := []data.Concept{
+		{Name: "iPhone", HeightM: pf(0.0078), WeightKg: pf(0.17)},
+		{Name: "Eiffel Tower", HeightM: pf(330)},
+		{Name: "Moon", DistanceM: pf(384400000)},
+		{Name: "African Elephant", WeightKg: pf(5000)},
+	}
+	store := makeStore(concepts)
+	store.ByDimension["distance"] = &data.DimensionIndex{
+		Entries: []data.IndexEntry{{Concept: &concepts[2], Value: 384400000}},
+	}
+
+	// 3 billion iPhones stacked reach 23,400 km, and weigh 510,000 t.
+	for i := 0; i < 20; i++ {
+		result, err := FindItemMatch(3e9, concepts[0], store, nil)
+		if err != nil {
+			t.Fatalf("FindItemMatch() error: %v", err)
+		}
+		if result.UnitItem.Name != "iPhone" {
+			t.Errorf("unit item = %q, want iPhone", result.UnitItem.Name)
+		}
+		if result.TargetItem.Name == "iPhone" {
+			t.Error("target should not be the item itself")
+		}
+	}
+
+	result, err := FindItemMatch(1e10, concepts[0], store, []string{"height"}, BestOnly())
+	if err != nil {
+		t.Fatalf("FindItemMatch() error: %v", err)
+	}
+	// 1e10 * 7.8mm = 78,000 km, about a fifth of the way to the Moon, but the
+	// stack is a nicer multiple of the Eiffel Tower's height... either way the
+	// dimension must be height.
+	if result.Dimension != "height" {
+		t.Errorf("dimension = %q, want height", result.Dimension)
+	}
+	if result.TargetItem.Name == "Moon" && result.TargetDimension != "distance" {
+		t.Errorf("target dimension = %q, want distance for the Moon", result.TargetDimension)
+	}
+	if result.TargetItem.Name == "Eiffel Tower" && result.TargetDimension != "" {
+		t.Errorf("target dimension = %q, want empty for a height target", result.TargetDimension)
+	}
+}
+
+func TestFindItemMatchReachesDistance(t *testing.T) {
+	concepts := []data.Concept{
+		{Name: "iPhone", HeightM: pf(0.0078)},
+		{Name: "Moon", DistanceM: pf(384400000)},
+	}
+	store := makeStore(concepts)
+	store.ByDimension["distance"] = &data.DimensionIndex{
+		Entries: []data.IndexEntry{{Concept: &concepts[1], Value: 384400000}},
+	}
+
+	result, err := FindItemMatch(2.5e10, concepts[0], store, nil)
+	if err != nil {
+		t.Fatalf("FindItemMatch() error: %v", err)
+	}
+	if result.TargetItem.Name != "Moon" || result.TargetDimension != "distance" || result.Dimension != "height" {
+		t.Errorf("got %s (%s vs %s), want Moon (height vs distance)",
+			result.TargetItem.Name, result.Dimension, result.TargetDimension)
+	}
+}
+
+func TestFindItemMatchNoDimensions(t *testing.T) {
+	concepts := []data.Concept{
+		{Name: "iPhone", HeightM: pf(0.0078)},
+		{Name: "African Elephant", WeightKg: pf(5000)},
+	}
+	store := makeStore(concepts)
+	if _, err := FindItemMatch(1000, concepts[0], store, []string{"weight"}); err == nil {
+		t.Error("expected error when the item has none of the requested dimensions")
+	}
+}
+
+func TestFindDimensionMatchNonAdditive(t *testing.T) {
+	concepts := []data.Concept{
+		{Name: "Cheetah", SpeedMPS: pf(29)},
+		{Name: "Rifle Bullet", SpeedMPS: pf(900)},
+	}
+	store := makeStore(concepts)
+	if _, err := FindDimensionMatch(30, "speed", store); err == nil {
+		t.Error("expected error counting a non-additive dimension")
+	}
+	if _, err := FindItemMatch(30, concepts[0], store, nil); err == nil {
+		t.Error("expected error counting an item with only a speed")
+	}
+}
+
+func TestFindUnitMatchTemperatureByDifference(t *testing.T) {
+	concepts := []data.Concept{
+		{Name: "Pot of Boiling Water", TemperatureK: pf(373.15)},
+		{Name: "Candle Flame", TemperatureK: pf(1673)},
+		{Name: "Dewar of Liquid Nitrogen", TemperatureK: pf(77.36)},
+	}
+	store := makeStore(concepts)
+
+	result, err := FindUnitMatch(1573.15, "temperature", store, BestOnly())
+	if err != nil {
+		t.Fatalf("FindUnitMatch() error: %v", err)
+	}
+	if result.Concept.Name != "Candle Flame" {
+		t.Errorf("concept = %q, want the nearest, Candle Flame", result.Concept.Name)
+	}
+	if math.Abs(result.Difference-(-99.85)) > 1e-9 {
+		t.Errorf("difference = %g, want -99.85", result.Difference)
+	}
+	if result.Ratio != 0 {
+		t.Errorf("ratio = %g, want unset for an interval dimension", result.Ratio)
+	}
+}
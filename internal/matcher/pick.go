@@ -0,0 +1,121 @@
+package matcher
+
+import (
+	"math"
+	"math/rand/v2"
+	"sort"
+)
+
+// Option configures how a match is picked among the top candidates.
+type Option func(*options)
+
+type options struct {
+	rng      *rand.Rand
+	bestOnly bool
+	emphasis Emphasis
+}
+
+// WithRand makes the matcher draw from r instead of the global random source.
+func WithRand(r *rand.Rand) Option {
+	return func(o *options) {
+		o.rng = r
+	}
+}
+
+// BestOnly disables random picking: the candidate with the lowest score wins,
+// with ties broken by concept name so the result is stable across runs.
+func BestOnly() Option {
+	return func(o *options) {
+		o.bestOnly = true
+	}
+}
+
+func newOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+func (o options) intN(n int) int {
+	if o.rng != nil {
+		return o.rng.IntN(n)
+	}
+	return rand.IntN(n)
+}
+
+// candidate is a scored match. key identifies the concept(s) involved; it is
+// used as a stable tie-breaker and to tell distinct analogies apart.
+type candidate[T any] struct {
+	result T
+	ratio  float64
+	score  float64
+	key    string
+}
+
+// less orders candidates by score, then by key.
+func (c candidate[T]) less(other candidate[T]) bool {
+	if c.score != other.score {
+		return c.score < other.score
+	}
+	return c.key < other.key
+}
+
+// pick chooses one of the candidates, which must be non-empty. By default it
+// picks uniformly among those scoring within scoreThreshold of the best.
+func pick[T any](candidates []candidate[T], o options) T {
+	if o.bestOnly {
+		best := candidates[0]
+		for _, c := range candidates[1:] {
+			if c.less(best) {
+				best = c
+			}
+		}
+		return best.result
+	}
+
+	bestScore := math.MaxFloat64
+	for _, c := range candidates {
+		if c.score < bestScore {
+			bestScore = c.score
+		}
+	}
+
+	var topCandidates []candidate[T]
+	for _, c := range candidates {
+		if c.score <= bestScore+scoreThreshold {
+			topCandidates = append(topCandidates, c)
+		}
+	}
+
+	return topCandidates[o.intN(len(topCandidates))].result
+}
+
+// top returns the results of up to n distinct candidates, best first.
+// Candidates sharing a key keep only the best-scoring one. If n <= 0, every
+// distinct candidate within scoreThreshold of the best is returned.
+func top[T any](candidates []candidate[T], n int) []T {
+	sorted := make([]candidate[T], len(candidates))
+	copy(sorted, candidates)
+	sort.Slice(sorted, func(a, b int) bool {
+		return sorted[a].less(sorted[b])
+	})
+
+	seen := make(map[string]bool)
+	var results []T
+	for _, c := range sorted {
+		if n > 0 && len(results) == n {
+			break
+		}
+		if n <= 0 && c.score > sorted[0].score+scoreThreshold {
+			break
+		}
+		if seen[c.key] {
+			continue
+		}
+		seen[c.key] = true
+		results = append(results, c.result)
+	}
+	return results
+}
@@ -0,0 +1,142 @@
+package matcher
+
+import (
+	"fmt"
+
+	"github.com/creimer/lnag/internal/data"
+)
+
+// rateWindows are the familiar spans of time a rate is scaled to.
+var rateWindows = []struct {
+	name    string
+	seconds float64
+}{
+	{"second", 1},
+	{"minute", 60},
+	{"hour", 3600},
+	{"day", 86400},
+	{"week", 604800},
+	{"year", 31557600},
+}
+
+// rateNiceNumbers are the multiples aimed for without emphasis. Since the
+// window can be chosen freely, a small count of the concept is always within
+// reach and reads better than "500 times".
+var rateNiceNumbers = []float64{1, 2, 3, 5, 10}
+
+// conceptWindowPenalty is added to the score of candidates whose window is a
+// duration concept, so "every minute" wins over "during a Human sneeze"
+// unless the concept gives a clearly nicer ratio.
+const conceptWindowPenalty = 0.05
+
+// maxConceptWindow is the longest duration concept used as a window.
+const maxConceptWindow = 100 * 31557600
+
+// RateResult is an analogy for a rate such as 40 tons per day: the amount
+// that adds up over a window of time, compared with a concept. The window is
+// either a familiar unit of time (Window is "minute", "day", ...) or a
+// duration concept (WindowConcept is set and Window is its name).
+// Total is the amount over the window in base units and Ratio is Total over
+// the concept's value.
+type RateResult struct {
+	Concept       data.Concept
+	Dimension     string
+	Ratio         float64
+	Total         float64
+	Seconds       float64
+	Window        string
+	WindowConcept *data.Concept
+	Emphasis      Emphasis
+}
+
+// FindRateMatch scales perSecond, in base units of dimension per second, to
+// the window of time in which it adds up to the nicest multiple of a
+// concept.
+func FindRateMatch(perSecond float64, dimension string, store *data.ConceptStore, opts ...Option) (RateResult, error) {
+	o := newOptions(opts)
+	candidates, err := rateCandidates(perSecond, dimension, store, o)
+	if err != nil {
+		return RateResult{}, err
+	}
+	return pick(candidates, o), nil
+}
+
+// FindRateMatches returns up to n distinct results for FindRateMatch, best
+// first. If n <= 0 it returns every result FindRateMatch could have picked.
+func FindRateMatches(perSecond float64, dimension string, store *data.ConceptStore, n int, opts ...Option) ([]RateResult, error) {
+	candidates, err := rateCandidates(perSecond, dimension, store, newOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+	return top(candidates, n), nil
+}
+
+func rateCandidates(perSecond float64, dimension string, store *data.ConceptStore, o options) ([]candidate[RateResult], error) {
+	if data.Interval(dimension) {
+		return nil, fmt.Errorf("dimension %q cannot be a rate", dimension)
+	}
+	if perSecond <= 0 {
+		return nil, fmt.Errorf("rate must be positive, got %g", perSecond)
+	}
+	idx, ok := store.ByDimension[dimension]
+	if !ok || len(idx.Entries) == 0 {
+		return nil, fmt.Errorf("no concepts for dimension %q", dimension)
+	}
+
+	type window struct {
+		name    string
+		seconds float64
+		concept *data.Concept
+		penalty float64
+	}
+	var windows []window
+	for _, w := range rateWindows {
+		windows = append(windows, window{name: w.name, seconds: w.seconds})
+	}
+	if durations, ok := store.ByDimension["duration"]; ok {
+		for _, e := range durations.Entries {
+			if e.Value >= 1 && e.Value <= maxConceptWindow {
+				windows = append(windows, window{e.Concept.Name, e.Value, e.Concept, conceptWindowPenalty})
+			}
+		}
+	}
+
+	nices := rateNiceNumbers
+	if o.emphasis != EmphasisNone {
+		nices = o.emphasis.niceNumbers()
+	}
+
+	var candidates []candidate[RateResult]
+	for _, w := range windows {
+		total := perSecond * w.seconds
+		for _, nice := range nices {
+			closest := idx.FindClosest(total / nice)
+			if closest == nil {
+				continue
+			}
+			ratio := total / closest.Value
+			if ratio < 0.01 || ratio > 100000 {
+				continue
+			}
+			candidates = append(candidates, candidate[RateResult]{
+				result: RateResult{
+					Concept:       *closest.Concept,
+					Dimension:     dimension,
+					Ratio:         ratio,
+					Total:         total,
+					Seconds:       w.seconds,
+					Window:        w.name,
+					WindowConcept: w.concept,
+				},
+				ratio: ratio,
+				score: scoreAgainst(ratio, nices) + w.penalty,
+				key:   w.name + "\x00" + closest.Concept.Name,
+			})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no valid rate comparison found for %g per second in %s", perSecond, dimension)
+	}
+	return emphasize(candidates, o.emphasis, func(r *RateResult) { r.Emphasis = o.emphasis }), nil
+}
@@ -0,0 +1,80 @@
+package matcher
+
+import (
+	"math"
+	"testing"
+
+	"github.com/creimer/lnag/internal/data"
+)
+
+func TestFindRateMatch(t *testing.T) {
+	concepts := []data.Concept{
+		{Name: "Bathtub", VolumeM3: pf(0.3)},
+		{Name: "Olympic Swimming Pool", VolumeM3: pf(2500)},
+	}
+	store := makeStore(concepts)
+
+	// 125 m³/s fills 3 Olympic pools every minute.
+	result, err := FindRateMatch(125, "volume", store, BestOnly())
+	if err != nil {
+		t.Fatalf("FindRateMatch() error: %v", err)
+	}
+	if result.Window != "minute" || result.Concept.Name != "Olympic Swimming Pool" {
+		t.Errorf("got %s every %s, want Olympic Swimming Pool every minute", result.Concept.Name, result.Window)
+	}
+	if math.Abs(result.Ratio-3) > 1e-9 || result.Seconds != 60 || result.Total != 7500 {
+		t.Errorf("got ratio %g over %g s (total %g), want 3 over 60 s (total 7500)", result.Ratio, result.Seconds, result.Total)
+	}
+}
+
+func TestFindRateMatchConceptWindow(t *testing.T) {
+	concepts := []data.Concept{
+		{Name: "Olympic Swimming Pool", VolumeM3: pf(2500)},
+		{Name: "Super Bowl Halftime Show", DurationS: pf(1000)},
+	}
+	store := makeStore(concepts)
+
+	// At 2.5 m³/s no familiar unit of time comes close to a nice number of
+	// pools (3.6 every hour), but one fills during the halftime show.
+	result, err := FindRateMatch(2.5, "volume", store, BestOnly())
+	if err != nil {
+		t.Fatalf("FindRateMatch() error: %v", err)
+	}
+	if result.WindowConcept == nil || result.Window != "Super Bowl Halftime Show" {
+		t.Errorf("window = %q, want the Super Bowl Halftime Show", result.Window)
+	}
+}
+
+func TestFindRateMatches(t *testing.T) {
+	concepts := []data.Concept{
+		{Name: "Bathtub", VolumeM3: pf(0.3)},
+		{Name: "Olympic Swimming Pool", VolumeM3: pf(2500)},
+	}
+	store := makeStore(concepts)
+
+	results, err := FindRateMatches(125, "volume", store, 3)
+	if err != nil {
+		t.Fatalf("FindRateMatches() error: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3", len(results))
+	}
+	seen := map[string]bool{}
+	for _, r := range results {
+		key := r.Window + "/" + r.Concept.Name
+		if seen[key] {
+			t.Errorf("duplicate result %s", key)
+		}
+		seen[key] = true
+	}
+}
+
+func TestFindRateMatchInvalid(t *testing.T) {
+	store := makeStore([]data.Concept{{Name: "Bathtub", VolumeM3: pf(0.3)}})
+	if _, err := FindRateMatch(0, "volume", store); err == nil {
+		t.Error("expected error for a zero rate")
+	}
+	if _, err := FindRateMatch(1, "weight", store); err == nil {
+		t.Error("expected error for a dimension without concepts")
+	}
+}
@@ -0,0 +1,135 @@
+package matcher
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/creimer/lnag/internal/data"
+)
+
+// timeTargets lists, for each dimension, the dimensions of concepts it can be
+// compared with over time: a speed covers a distance, length or width; a
+// power releases an amount of energy; an amount of energy powers a consumer.
+var timeTargets = map[string][]string{
+	"speed":  {"distance", "length", "width"},
+	"power":  {"energy"},
+	"energy": {"power"},
+}
+
+// rates are the dimensions measured per second.
+var rates = map[string]bool{"speed": true, "power": true}
+
+// timeUnits are the units a derived duration is expressed in, largest first.
+var timeUnits = []struct {
+	name    string
+	seconds float64
+}{
+	{"year", 31557600},
+	{"day", 86400},
+	{"hour", 3600},
+	{"minute", 60},
+	{"second", 1},
+}
+
+// TimeResult is an analogy derived from a rate: how long the input rate takes
+// to get through a concept, e.g. 60 km/s reaching the Moon in about 2 hours,
+// or how long an input amount lasts at a concept's rate, e.g. 1 MWh powering
+// a household for a month.
+// Dimension is the input's dimension and TargetDimension the concept's.
+// Seconds is the duration; Value is the same duration in Unit ("hour",
+// "day", ...).
+type TimeResult struct {
+	Concept         data.Concept
+	Dimension       string
+	TargetDimension string
+	Seconds         float64
+	Value           float64
+	Unit            string
+}
+
+// FindTimeMatch finds the concept that value, in base units of dimension,
+// takes or lasts the nicest whole number of minutes, hours, days or years.
+func FindTimeMatch(value float64, dimension string, store *data.ConceptStore, opts ...Option) (TimeResult, error) {
+	o := newOptions(opts)
+	candidates, err := timeCandidates(value, dimension, store, o)
+	if err != nil {
+		return TimeResult{}, err
+	}
+	return pick(candidates, o), nil
+}
+
+// FindTimeMatches returns up to n distinct concepts for FindTimeMatch, best
+// first. If n <= 0 it returns every concept FindTimeMatch could have picked.
+func FindTimeMatches(value float64, dimension string, store *data.ConceptStore, n int, opts ...Option) ([]TimeResult, error) {
+	candidates, err := timeCandidates(value, dimension, store, newOptions(opts))
+	if err != nil {
+		return nil, err
+	}
+	return top(candidates, n), nil
+}
+
+// ErrTimeEmphasis is returned by FindTimeMatch and FindTimeMatches under
+// WithEmphasis: a duration is always at least one whole minute, hour, day
+// or year, so it is never the fraction or multiple emphasis asks for.
+var ErrTimeEmphasis = errors.New("emphasis does not apply to time analogies")
+
+func timeCandidates(value float64, dimension string, store *data.ConceptStore, o options) ([]candidate[TimeResult], error) {
+	if o.emphasis != EmphasisNone {
+		return nil, ErrTimeEmphasis
+	}
+	targets, ok := timeTargets[dimension]
+	if !ok {
+		return nil, fmt.Errorf("dimension %q cannot be compared over time", dimension)
+	}
+	if value <= 0 {
+		return nil, fmt.Errorf("value must be positive, got %g", value)
+	}
+
+	var candidates []candidate[TimeResult]
+	for _, dim := range targets {
+		idx, ok := store.ByDimension[dim]
+		if !ok {
+			continue
+		}
+		for _, e := range idx.Entries {
+			seconds := e.Value / value
+			if rates[dim] {
+				seconds = value / e.Value
+			}
+			if seconds < 1 || seconds > 1000*timeUnits[0].seconds {
+				continue
+			}
+			inUnit, unit := inTimeUnit(seconds)
+			candidates = append(candidates, candidate[TimeResult]{
+				result: TimeResult{
+					Concept:         *e.Concept,
+					Dimension:       dimension,
+					TargetDimension: dim,
+					Seconds:         seconds,
+					Value:           inUnit,
+					Unit:            unit,
+				},
+				ratio: inUnit,
+				score: ScoreRatio(inUnit),
+				key:   dim + "\x00" + e.Concept.Name,
+			})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return nil, fmt.Errorf("no valid time comparison found for %g in %s", value, dimension)
+	}
+	return candidates, nil
+}
+
+// inTimeUnit expresses seconds in the largest unit that keeps the value at
+// least 1.
+func inTimeUnit(seconds float64) (float64, string) {
+	for _, u := range timeUnits {
+		if seconds >= u.seconds {
+			return seconds / u.seconds, u.name
+		}
+	}
+	last := timeUnits[len(timeUnits)-1]
+	return seconds / last.seconds, last.name
+}
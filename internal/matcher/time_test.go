@@ -0,0 +1,127 @@
+package matcher
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/creimer/lnag/internal/data"
+)
+
+func TestFindTimeMatch(t *testing.T) {
+	concepts := []data.Concept{
+		{Name: "Moon", DistanceM: pf(384400000), ProperNoun: true},
+		{Name: "Atlantic Ocean", WidthM: pf(4800000), ProperNoun: true},
+		{Name: "Cheetah", SpeedMPS: pf(29)},
+	}
+	store := makeStore(concepts)
+
+	// 400 km/s reaches the Moon in 961 s (16 minutes) and crosses the
+	// Atlantic in 12 s; 12 seconds is the nicer number.
+	result, err := FindTimeMatch(400000, "speed", store, BestOnly())
+	if err != nil {
+		t.Fatalf("FindTimeMatch() error: %v", err)
+	}
+	if result.Concept.Name != "Atlantic Ocean" || result.TargetDimension != "width" {
+		t.Errorf("got %s (%s), want Atlantic Ocean (width)", result.Concept.Name, result.TargetDimension)
+	}
+
+	// 960 km/h crosses the Atlantic in 5 hours and reaches the Moon in 16.7 days.
+	result, err = FindTimeMatch(960/3.6, "speed", store, BestOnly())
+	if err != nil {
+		t.Fatalf("FindTimeMatch() error: %v", err)
+	}
+	if result.Unit != "hour" || result.Value < 4.99 || result.Value > 5.01 {
+		t.Errorf("got %f %s, want 5 hour", result.Value, result.Unit)
+	}
+	if result.Seconds < 17990 || result.Seconds > 18010 {
+		t.Errorf("seconds = %f, want 18000", result.Seconds)
+	}
+}
+
+func TestFindTimeMatches(t *testing.T) {
+	concepts := []data.Concept{
+		{Name: "Moon", DistanceM: pf(384400000), ProperNoun: true},
+		{Name: "Atlantic Ocean", WidthM: pf(4800000), ProperNoun: true},
+		{Name: "Amazon River", LengthM: pf(6400000), ProperNoun: true},
+	}
+	store := makeStore(concepts)
+
+	results, err := FindTimeMatches(250, "speed", store, 0)
+	if err != nil {
+		t.Fatalf("FindTimeMatches() error: %v", err)
+	}
+	if len(results) == 0 {
+		t.Fatal("expected at least one result")
+	}
+	for _, r := range results {
+		if r.Dimension != "speed" {
+			t.Errorf("dimension = %q, want speed", r.Dimension)
+		}
+	}
+}
+
+func TestFindTimeMatchNotARate(t *testing.T) {
+	store := makeStore([]data.Concept{{Name: "Moon", DistanceM: pf(384400000)}})
+	if _, err := FindTimeMatch(5, "length", store); err == nil {
+		t.Error("expected error for a dimension that is not a rate")
+	}
+}
+
+func TestInTimeUnit(t *testing.T) {
+	tests := []struct {
+		seconds   float64
+		wantValue float64
+		wantUnit  string
+	}{
+		{30, 30, "second"},
+		{90, 1.5, "minute"},
+		{7200, 2, "hour"},
+		{3 * 86400, 3, "day"},
+		{31557600 * 2, 2, "year"},
+	}
+	for _, tt := range tests {
+		value, unit := inTimeUnit(tt.seconds)
+		if value != tt.wantValue || unit != tt.wantUnit {
+			t.Errorf("inTimeUnit(%g) = %g %s, want %g %s", tt.seconds, value, unit, tt.wantValue, tt.wantUnit)
+		}
+	}
+}
+
+func TestFindTimeMatchEnergy(t *testing.T) {
+	concepts := []data.Concept{
+		{Name: "Light Bulb", PowerW: pf(60)},
+		{Name: "Lightning Bolt", EnergyJ: pf(1e9)},
+	}
+	store := makeStore(concepts)
+
+	// 1 kWh powers a 60 W bulb for 60,000 s (about 16.7 hours).
+	result, err := FindTimeMatch(3.6e6, "energy", store)
+	if err != nil {
+		t.Fatalf("FindTimeMatch() error: %v", err)
+	}
+	if result.Concept.Name != "Light Bulb" || result.Seconds != 60000 {
+		t.Errorf("got %s for %g s, want Light Bulb for 60000 s", result.Concept.Name, result.Seconds)
+	}
+
+	// 1 MW releases a lightning bolt's energy in 1,000 s.
+	result, err = FindTimeMatch(1e6, "power", store)
+	if err != nil {
+		t.Fatalf("FindTimeMatch() error: %v", err)
+	}
+	if result.Concept.Name != "Lightning Bolt" || result.Seconds != 1000 {
+		t.Errorf("got %s for %g s, want Lightning Bolt for 1000 s", result.Concept.Name, result.Seconds)
+	}
+}
+
+func TestFindTimeMatchEmphasis(t *testing.T) {
+	store := makeStore([]data.Concept{
+		{Name: "Moon", DistanceM: pf(384400000), ProperNoun: true},
+		{Name: "Atlantic Ocean", WidthM: pf(4800000), ProperNoun: true},
+	})
+	if _, err := FindTimeMatch(400000, "speed", store, WithEmphasis(EmphasisSmall)); !errors.Is(err, ErrTimeEmphasis) {
+		t.Errorf("FindTimeMatch() error = %v, want ErrTimeEmphasis", err)
+	}
+	if _, err := FindTimeMatches(400000, "speed", store, 0, WithEmphasis(EmphasisLarge)); !errors.Is(err, ErrTimeEmphasis) {
+		t.Errorf("FindTimeMatches() error = %v, want ErrTimeEmphasis", err)
+	}
+}
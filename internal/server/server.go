@@ -0,0 +1,214 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/creimer/lnag/internal/data"
+	"github.com/creimer/lnag/lnag"
+)
+
+// AnalogiesResponse is the JSON body returned by GET /v1/analogies.
+type AnalogiesResponse struct {
+	Results []lnag.Result `json:"results"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Server serves analogies over HTTP from a single Generator. GET /v1/analogy
+// responds with a JSON-encoded lnag.Result; GET /v1/analogies responds with
+// the n best results (all of them if n is 0). Both take value, which may
+// carry its own unit ("3.2 million km"), plus at most one of unit or
+// dimension; item fixes the concept being counted and may be combined with
+// dimension; time=true expresses a speed as the time it takes to cover a
+// concept; cite=true adds the citations of the concepts used.
+type Server struct {
+	gen *lnag.Generator
+	mux *http.ServeMux
+}
+
+func New(gen *lnag.Generator) *Server {
+	s := &Server{gen: gen, mux: http.NewServeMux()}
+	s.mux.HandleFunc("GET /v1/analogy", s.handleAnalogy)
+	s.mux.HandleFunc("GET /v1/analogies", s.handleAnalogies)
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// errBadRequest marks errors caused by the caller's query parameters.
+var errBadRequest = errors.New("bad request")
+
+func (s *Server) handleAnalogy(w http.ResponseWriter, r *http.Request) {
+	q, err := s.parseQuery(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	var res lnag.Result
+	switch {
+	case q.time:
+		res, err = q.gen.AnalogizeTime(q.value, q.unit)
+	case q.unit != "":
+		res, err = q.gen.Analogize(q.value, q.unit)
+	case q.item != "":
+		res, err = q.gen.AnalogizeItem(q.value, q.item, q.dimension)
+	default:
+		res, err = q.gen.AnalogizeCount(q.value, q.dimension)
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+const defaultTopN = 5
+
+func (s *Server) handleAnalogies(w http.ResponseWriter, r *http.Request) {
+	q, err := s.parseQuery(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	n := defaultTopN
+	if nStr := r.URL.Query().Get("n"); nStr != "" {
+		if n, err = strconv.Atoi(nStr); err != nil || n < 0 {
+			writeError(w, fmt.Errorf("%w: n must be a non-negative integer, got %q", errBadRequest, nStr))
+			return
+		}
+	}
+	var results []lnag.Result
+	switch {
+	case q.time:
+		results, err = q.gen.AnalogizeTimeTop(q.value, q.unit, n)
+	case q.unit != "":
+		results, err = q.gen.AnalogizeTop(q.value, q.unit, n)
+	case q.item != "":
+		results, err = q.gen.AnalogizeItemTop(q.value, q.item, q.dimension, n)
+	default:
+		results, err = q.gen.AnalogizeCountTop(q.value, q.dimension, n)
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, AnalogiesResponse{Results: results})
+}
+
+// query holds the parameters shared by both endpoints. gen is the server's
+// Generator with any per-request options applied.
+type query struct {
+	value     float64
+	unit      string
+	dimension string
+	item      string
+	time      bool
+	gen       *lnag.Generator
+}
+
+func (s *Server) parseQuery(r *http.Request) (query, error) {
+	q := r.URL.Query()
+	valueStr := q.Get("value")
+	unit := q.Get("unit")
+	dimension := q.Get("dimension")
+	item := q.Get("item")
+
+	if valueStr == "" {
+		return query{}, fmt.Errorf("%w: missing value parameter", errBadRequest)
+	}
+	quantity, err := lnag.ParseQuantity(valueStr)
+	if err != nil {
+		return query{}, fmt.Errorf("%w: %v", errBadRequest, err)
+	}
+	value := quantity.Value
+	if quantity.Unit != "" {
+		if unit != "" {
+			return query{}, fmt.Errorf("%w: value %q already has a unit", errBadRequest, valueStr)
+		}
+		unit = quantity.Unit
+	}
+	if unit != "" && dimension != "" {
+		return query{}, fmt.Errorf("%w: at most one of unit or dimension may be provided", errBadRequest)
+	}
+	if unit != "" && item != "" {
+		return query{}, fmt.Errorf("%w: unit cannot be combined with item", errBadRequest)
+	}
+	if dimension != "" && !slices.Contains(data.Dimensions(), dimension) {
+		return query{}, fmt.Errorf("%w: unknown dimension %q (valid: %s)", errBadRequest, dimension, strings.Join(data.Dimensions(), ", "))
+	}
+
+	var timeMode bool
+	if t := q.Get("time"); t != "" {
+		if timeMode, err = strconv.ParseBool(t); err != nil {
+			return query{}, fmt.Errorf("%w: time must be true or false, got %q", errBadRequest, t)
+		}
+		if timeMode && unit == "" {
+			return query{}, fmt.Errorf("%w: time needs a unit", errBadRequest)
+		}
+	}
+
+	var opts []lnag.Option
+	if e := q.Get("emphasize"); e != "" {
+		emphasis, err := lnag.ParseEmphasis(e)
+		if err != nil {
+			return query{}, fmt.Errorf("%w: %v", errBadRequest, err)
+		}
+		opts = append(opts, lnag.WithEmphasis(emphasis))
+	}
+	if l := q.Get("locale"); l != "" {
+		locale, err := lnag.ParseLocale(l)
+		if err != nil {
+			return query{}, fmt.Errorf("%w: %v", errBadRequest, err)
+		}
+		opts = append(opts, lnag.WithLocale(locale))
+	}
+	if u := q.Get("units"); u != "" {
+		system, err := lnag.ParseUnitSystem(u)
+		if err != nil {
+			return query{}, fmt.Errorf("%w: %v", errBadRequest, err)
+		}
+		opts = append(opts, lnag.WithUnitSystem(system))
+	}
+	if c := q.Get("cite"); c != "" {
+		cite, err := strconv.ParseBool(c)
+		if err != nil {
+			return query{}, fmt.Errorf("%w: cite must be true or false, got %q", errBadRequest, c)
+		}
+		if cite {
+			opts = append(opts, lnag.WithCitations())
+		}
+	}
+	gen := s.gen
+	if len(opts) > 0 {
+		if gen, err = s.gen.With(opts...); err != nil {
+			return query{}, err
+		}
+	}
+
+	return query{value: value, unit: unit, dimension: dimension, item: item, time: timeMode, gen: gen}, nil
+}
+
+// writeError reports caller mistakes as 400 and failed matches as 422.
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusUnprocessableEntity
+	if errors.Is(err, errBadRequest) || errors.Is(err, lnag.ErrUnknownUnit) || errors.Is(err, lnag.ErrUnknownConcept) || errors.Is(err, lnag.ErrTimeEmphasis) {
+		status = http.StatusBadRequest
+	}
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,258 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/creimer/lnag/lnag"
+)
+
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	gen, err := lnag.New()
+	if err != nil {
+		t.Fatalf("lnag.New() error: %v", err)
+	}
+	return New(gen)
+}
+
+func get(t *testing.T, s *Server, target string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestAnalogyUnit(t *testing.T) {
+	s := newTestServer(t)
+	rec := get(t, s, "/v1/analogy?value=500&unit=m")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body %s", rec.Code, rec.Body)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+
+	var resp lnag.Result
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	if resp.Sentence == "" {
+		t.Error("sentence is empty")
+	}
+	if resp.Dimension != "length" && resp.Dimension != "distance" {
+		t.Errorf("dimension = %q, want length or distance", resp.Dimension)
+	}
+	if resp.Concept == nil {
+		t.Error("concept should be set for unit queries")
+	}
+	if resp.Ratio <= 0 {
+		t.Errorf("ratio = %f, want > 0", resp.Ratio)
+	}
+}
+
+func TestAnalogyDimension(t *testing.T) {
+	s := newTestServer(t)
+	rec := get(t, s, "/v1/analogy?value=2000&dimension=weight")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body %s", rec.Code, rec.Body)
+	}
+
+	var resp lnag.Result
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	if resp.Dimension != "weight" {
+		t.Errorf("dimension = %q, want weight", resp.Dimension)
+	}
+	if resp.Count != 2000 {
+		t.Errorf("count = %f, want 2000", resp.Count)
+	}
+	if resp.UnitItem == nil || resp.TargetItem == nil {
+		t.Error("unit_item and target_item should be set for dimension queries")
+	}
+}
+
+func TestAnalogyBadRequests(t *testing.T) {
+	s := newTestServer(t)
+	tests := []struct {
+		name   string
+		target string
+		want   int
+	}{
+		{"missing value", "/v1/analogy?unit=m", http.StatusBadRequest},
+		{"invalid value", "/v1/analogy?value=abc&unit=m", http.StatusBadRequest},
+		{"both unit and dimension", "/v1/analogy?value=5&unit=m&dimension=weight", http.StatusBadRequest},
+		{"unknown unit", "/v1/analogy?value=5&unit=cubits", http.StatusBadRequest},
+		{"unknown dimension", "/v1/analogy?value=5&dimension=smell", http.StatusBadRequest},
+		{"uncountable dimension", "/v1/analogy?value=5&dimension=speed", http.StatusUnprocessableEntity},
+		{"unknown emphasis", "/v1/analogy?value=5&unit=m&emphasize=huge", http.StatusBadRequest},
+		{"unknown locale", "/v1/analogy?value=5&unit=tons&locale=fr-FR", http.StatusBadRequest},
+		{"unknown unit system", "/v1/analogy?value=5&unit=m&units=cubits", http.StatusBadRequest},
+		{"invalid cite", "/v1/analogy?value=5&unit=m&cite=please", http.StatusBadRequest},
+		{"unit given twice", "/v1/analogy?value=5+km&unit=m", http.StatusBadRequest},
+		{"time without unit", "/v1/analogy?value=5&time=true", http.StatusBadRequest},
+		{"time with emphasis", "/v1/analogy?value=5&unit=km/s&time=true&emphasize=small", http.StatusBadRequest},
+		{"unit and item", "/v1/analogy?value=5&unit=m&item=iphone", http.StatusBadRequest},
+		{"unknown item", "/v1/analogy?value=5&item=flux+capacitor", http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := get(t, s, tt.target)
+			if rec.Code != tt.want {
+				t.Errorf("status = %d, want %d", rec.Code, tt.want)
+			}
+			var resp errorResponse
+			if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+				t.Fatalf("decode error: %v", err)
+			}
+			if resp.Error == "" {
+				t.Error("error message is empty")
+			}
+		})
+	}
+}
+
+func TestAnalogyMethodNotAllowed(t *testing.T) {
+	s := newTestServer(t)
+	req := httptest.NewRequest(http.MethodPost, "/v1/analogy?value=5&unit=m", nil)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("status = %d, want 405", rec.Code)
+	}
+}
+
+func TestAnalogies(t *testing.T) {
+	s := newTestServer(t)
+	rec := get(t, s, "/v1/analogies?value=500&unit=m&n=3")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body %s", rec.Code, rec.Body)
+	}
+	var resp AnalogiesResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	if len(resp.Results) != 3 {
+		t.Errorf("got %d results, want 3", len(resp.Results))
+	}
+
+	rec = get(t, s, "/v1/analogies?value=2000&dimension=weight")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body %s", rec.Code, rec.Body)
+	}
+	resp = AnalogiesResponse{}
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	if len(resp.Results) != defaultTopN {
+		t.Errorf("got %d results, want %d", len(resp.Results), defaultTopN)
+	}
+}
+
+func TestAnalogiesBadN(t *testing.T) {
+	s := newTestServer(t)
+	rec := get(t, s, "/v1/analogies?value=500&unit=m&n=-1")
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", rec.Code)
+	}
+}
+
+func TestAnalogyEmphasize(t *testing.T) {
+	s := newTestServer(t)
+	rec := get(t, s, "/v1/analogies?value=50&unit=m&emphasize=small&n=5")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body %s", rec.Code, rec.Body)
+	}
+	var resp AnalogiesResponse
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	for _, res := range resp.Results {
+		if res.Ratio >= 1 {
+			t.Errorf("emphasize=small gave ratio %f: %q", res.Ratio, res.Sentence)
+		}
+	}
+}
+
+func TestAnalogyAnyDimension(t *testing.T) {
+	s := newTestServer(t)
+	rec := get(t, s, "/v1/analogy?value=3000000")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body %s", rec.Code, rec.Body)
+	}
+	var resp lnag.Result
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	if resp.Dimension == "" {
+		t.Error("dimension should report the one that was picked")
+	}
+}
+
+func TestAnalogyItem(t *testing.T) {
+	s := newTestServer(t)
+	rec := get(t, s, "/v1/analogy?value=3000000&item=iphone&dimension=height")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body %s", rec.Code, rec.Body)
+	}
+	var resp lnag.Result
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	if resp.UnitItem == nil || resp.UnitItem.Name != "Smartphone (iPhone 14)" {
+		t.Errorf("unit_item = %v, want Smartphone (iPhone 14)", resp.UnitItem)
+	}
+	if resp.Dimension != "height" {
+		t.Errorf("dimension = %q, want height", resp.Dimension)
+	}
+}
+
+func TestAnalogyQuantityValue(t *testing.T) {
+	s := newTestServer(t)
+	rec := get(t, s, "/v1/analogy?value=3.2+million+km")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body %s", rec.Code, rec.Body)
+	}
+	var resp lnag.Result
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	if resp.Value != 3.2e6 || resp.Unit != "km" {
+		t.Errorf("value, unit = %g, %q, want 3.2e6, km", resp.Value, resp.Unit)
+	}
+}
+
+func TestAnalogyTime(t *testing.T) {
+	s := newTestServer(t)
+	rec := get(t, s, "/v1/analogy?value=60&unit=km/s&time=true")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body %s", rec.Code, rec.Body)
+	}
+	var resp lnag.Result
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	if resp.Dimension != "speed" || resp.Seconds <= 0 || resp.Concept == nil {
+		t.Errorf("got dimension %q, seconds %g, concept %v; want a speed result", resp.Dimension, resp.Seconds, resp.Concept)
+	}
+}
+
+func TestAnalogyCite(t *testing.T) {
+	s := newTestServer(t)
+	rec := get(t, s, "/v1/analogy?value=3000000&item=iphone&dimension=height&cite=true")
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200; body %s", rec.Code, rec.Body)
+	}
+	var resp lnag.Result
+	if err := json.NewDecoder(rec.Body).Decode(&resp); err != nil {
+		t.Fatalf("decode error: %v", err)
+	}
+	if len(resp.Citations) != 2 || resp.Citations[0].Concept != "Smartphone (iPhone 14)" {
+		t.Errorf("citations = %v, want the unit and target items", resp.Citations)
+	}
+}
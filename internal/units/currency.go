@@ -0,0 +1,112 @@
+package units
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// currencySpellings are the symbols and names known for common currency
+// codes. A rate for any other code makes only the code itself resolvable.
+var currencySpellings = map[string]struct {
+	aliases []string
+	names   []string
+}{
+	"EUR": {[]string{"€"}, []string{"euro"}},
+	"GBP": {[]string{"£"}, []string{"british pound", "pound sterling"}},
+	"JPY": {[]string{"¥"}, []string{"yen"}},
+	"CNY": {[]string{"RMB"}, []string{"yuan", "renminbi"}},
+	"INR": {[]string{"₹"}, []string{"rupee"}},
+	"CHF": {nil, []string{"swiss franc"}},
+	"CAD": {[]string{"C$"}, []string{"canadian dollar"}},
+	"AUD": {[]string{"A$"}, []string{"australian dollar"}},
+}
+
+// currencySymbols are the symbols ParseQuantity accepts in front of a
+// number ("$3.2 billion"), longest first so "US$" wins over "$".
+var currencySymbols = []string{"US$", "C$", "A$", "$", "€", "£", "¥", "₹"}
+
+// SetRates makes the currencies in usdPerUnit resolvable as money, converted
+// to US dollars at the given rates, e.g. {"EUR": 1.09}. Codes are
+// upper-case ISO 4217 codes. Rates are process-wide and may be set while
+// units are resolved on other goroutines; each lookup sees all of a call's
+// rates or none.
+func SetRates(usdPerUnit map[string]float64) error {
+	codes := make([]string, 0, len(usdPerUnit))
+	for code, rate := range usdPerUnit {
+		if len(code) != 3 || strings.ToUpper(code) != code {
+			return fmt.Errorf("currency code %q must be three upper-case letters", code)
+		}
+		if !(rate > 0) || math.IsInf(rate, 0) {
+			return fmt.Errorf("rate for %s must be positive, got %g", code, rate)
+		}
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+
+	tablesMu.Lock()
+	defer tablesMu.Unlock()
+	for _, code := range codes {
+		if code == "USD" {
+			continue
+		}
+		sp := currencySpellings[code]
+		register(&unitDef{
+			dimension: "money",
+			toBase:    usdPerUnit[code],
+			aliases:   append([]string{code}, sp.aliases...),
+			names:     sp.names,
+		})
+	}
+	return nil
+}
+
+// rateFile is the format read by LoadRatesFile.
+type rateFile struct {
+	USDPerUnit map[string]float64 `json:"usd_per_unit"`
+}
+
+// LoadRatesFile reads currency rates from a JSON file of the form
+// {"usd_per_unit": {"EUR": 1.09, "GBP": 1.27}} and passes them to SetRates.
+func LoadRatesFile(path string) error {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading rates: %w", err)
+	}
+	var f rateFile
+	if err := json.Unmarshal(b, &f); err != nil {
+		return fmt.Errorf("parsing rates %s: %w", path, err)
+	}
+	if len(f.USDPerUnit) == 0 {
+		return fmt.Errorf("rates %s: no usd_per_unit entries", path)
+	}
+	return SetRates(f.USDPerUnit)
+}
+
+// currencyCode returns the code of a known currency spelled unit, so an
+// error can point out that its rate is missing rather than the unit.
+func currencyCode(unit string) (string, bool) {
+	unit = strings.TrimSpace(unit)
+	lower := strings.ToLower(unit)
+	for code, sp := range currencySpellings {
+		if unit == code || slices.Contains(sp.aliases, unit) {
+			return code, true
+		}
+		for _, n := range sp.names {
+			if lower == n || lower == n+"s" {
+				return code, true
+			}
+		}
+	}
+	return "", false
+}
+
+// IsCurrencySymbol reports whether unit is written in front of an amount,
+// as in "$5" or "€20", rather than after it.
+func IsCurrencySymbol(unit string) bool {
+	return slices.Contains(currencySymbols, unit)
+}
@@ -0,0 +1,117 @@
+package units
+
+import (
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestResolveDollars(t *testing.T) {
+	for _, unit := range []string{"USD", "$", "US$", "dollars", "US dollar"} {
+		info, err := Resolve(unit)
+		if err != nil {
+			t.Fatalf("Resolve(%q) error: %v", unit, err)
+		}
+		if info.Dimension != "money" || info.ToBase != 1 {
+			t.Errorf("Resolve(%q) = %+v, want money with ToBase 1", unit, info)
+		}
+	}
+}
+
+func TestSetRates(t *testing.T) {
+	if err := SetRates(map[string]float64{"EUR": 1.09, "XTS": 2}); err != nil {
+		t.Fatalf("SetRates() error: %v", err)
+	}
+	tests := []struct {
+		unit       string
+		wantToBase float64
+	}{
+		{"EUR", 1.09},
+		{"€", 1.09},
+		{"euros", 1.09},
+		{"XTS", 2},
+	}
+	for _, tt := range tests {
+		info, err := Resolve(tt.unit)
+		if err != nil {
+			t.Fatalf("Resolve(%q) error: %v", tt.unit, err)
+		}
+		if info.Dimension != "money" || math.Abs(info.ToBase-tt.wantToBase) > 1e-12 {
+			t.Errorf("Resolve(%q) = %+v, want money with ToBase %g", tt.unit, info, tt.wantToBase)
+		}
+	}
+}
+
+// TestSetRatesConcurrent is meant for go test -race: rates loaded while
+// other goroutines resolve units must not race on the lookup tables.
+func TestSetRatesConcurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			if err := SetRates(map[string]float64{"EUR": 1.09, "GBP": 1.27}); err != nil {
+				t.Errorf("SetRates() error: %v", err)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for _, unit := range []string{"km", "EUR", "tonz per day", "£"} {
+				Resolve(unit)
+			}
+		}()
+	}
+	wg.Wait()
+	if info, err := Resolve("GBP"); err != nil || info.ToBase != 1.27 {
+		t.Errorf("Resolve(GBP) = %+v, %v, want ToBase 1.27", info, err)
+	}
+}
+
+func TestSetRatesInvalid(t *testing.T) {
+	for _, rates := range []map[string]float64{
+		{"eur": 1.09},
+		{"EURO": 1.09},
+		{"EUR": 0},
+		{"EUR": math.NaN()},
+	} {
+		if err := SetRates(rates); err == nil {
+			t.Errorf("SetRates(%v) should return error", rates)
+		}
+	}
+}
+
+func TestResolveCurrencyWithoutRate(t *testing.T) {
+	// No test loads a CHF rate.
+	_, err := Resolve("CHF")
+	if !errors.Is(err, ErrUnknownUnit) {
+		t.Fatalf("Resolve(CHF) error = %v, want ErrUnknownUnit", err)
+	}
+	if !strings.Contains(err.Error(), "no exchange rate for CHF") {
+		t.Errorf("error = %q, want it to mention the missing rate", err)
+	}
+}
+
+func TestLoadRatesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "rates.json")
+	if err := os.WriteFile(path, []byte(`{"usd_per_unit": {"GBP": 1.27}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := LoadRatesFile(path); err != nil {
+		t.Fatalf("LoadRatesFile() error: %v", err)
+	}
+	v, dim, err := Convert(100, "£")
+	if err != nil {
+		t.Fatalf("Convert(100, £) error: %v", err)
+	}
+	if dim != "money" || math.Abs(v-127) > 1e-9 {
+		t.Errorf("Convert(100, £) = %g %s, want 127 money", v, dim)
+	}
+
+	if err := LoadRatesFile(filepath.Join(t.TempDir(), "missing.json")); err == nil {
+		t.Error("LoadRatesFile(missing) should return error")
+	}
+}
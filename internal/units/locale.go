@@ -0,0 +1,109 @@
+package units
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Locale decides what everyday unit names that differ between countries
+// mean, such as "ton" and "gallon".
+type Locale string
+
+const (
+	EnUS Locale = "en-US" // short tons, US gallons and fluid ounces
+	EnGB Locale = "en-GB" // long tons, imperial gallons and fluid ounces
+)
+
+// DefaultLocale is used by Resolve and Convert.
+const DefaultLocale = EnUS
+
+// ParseLocale parses a locale such as "en-GB" or "en_us". The empty string
+// is DefaultLocale.
+func ParseLocale(s string) (Locale, error) {
+	switch strings.ToLower(strings.ReplaceAll(s, "_", "-")) {
+	case "":
+		return DefaultLocale, nil
+	case "en-us":
+		return EnUS, nil
+	case "en-gb", "en-uk":
+		return EnGB, nil
+	default:
+		return "", fmt.Errorf("unknown locale %q (want en-US or en-GB)", s)
+	}
+}
+
+// variant is a unit name whose meaning depends on the locale. units holds
+// the unambiguous unit each locale means by it.
+type variant struct {
+	spellings    []string // lowercase
+	name, plural string
+	units        map[Locale]string
+}
+
+var variants = []variant{
+	{
+		spellings: []string{"ton", "tons"},
+		name:      "ton", plural: "tons",
+		units: map[Locale]string{EnUS: "short ton", EnGB: "long ton"},
+	},
+	{
+		spellings: []string{"gallon", "gallons", "gal"},
+		name:      "gallon", plural: "gallons",
+		units: map[Locale]string{EnUS: "US gallon", EnGB: "imperial gallon"},
+	},
+	{
+		spellings: []string{"fluid ounce", "fluid ounces", "fl oz", "fl. oz."},
+		name:      "fluid ounce", plural: "fluid ounces",
+		units: map[Locale]string{EnUS: "US fluid ounce", EnGB: "imperial fluid ounce"},
+	},
+}
+
+// variantOf returns the variant unit is an ambiguous spelling of, if any.
+func variantOf(unit string) *variant {
+	lower := strings.ToLower(unit)
+	for i := range variants {
+		for _, s := range variants[i].spellings {
+			if lower == s {
+				return &variants[i]
+			}
+		}
+	}
+	return nil
+}
+
+// Label returns unit as l's readers should see it next to value: a unit
+// that is the locale's own meaning of an everyday name takes that name
+// ("long_ton" is "tons" in en-GB), and one that is not is qualified ("tons"
+// read in en-US is "short tons" to en-GB readers). Rates are labelled by
+// their numerator. Other units are returned as written.
+func (l Locale) Label(unit string, value float64) string {
+	if num, _, ok := SplitRate(unit); ok {
+		return l.Label(num, value) + strings.TrimPrefix(unit, num)
+	}
+	info, ok := l.parse(unit)
+	if !ok {
+		return unit
+	}
+	for _, v := range variants {
+		for loc, name := range v.units {
+			if other, _ := l.parse(name); other != info {
+				continue
+			}
+			if loc == l {
+				if variantOf(unit) != nil {
+					return unit
+				}
+				name = v.name
+				if value != 1 {
+					name = v.plural
+				}
+				return name
+			}
+			if value != 1 {
+				name += "s"
+			}
+			return name
+		}
+	}
+	return unit
+}
@@ -0,0 +1,103 @@
+package units
+
+import (
+	"math"
+	"testing"
+)
+
+func TestLocaleResolve(t *testing.T) {
+	tests := []struct {
+		locale     Locale
+		unit       string
+		wantToBase float64
+	}{
+		{EnUS, "tons", 907.18474},
+		{EnGB, "tons", 1016.0469088},
+		{EnGB, "Ton", 1016.0469088},
+		{EnUS, "gallons", 0.003785411784},
+		{EnGB, "gallons", 0.00454609},
+		{EnGB, "gal", 0.00454609},
+		{EnUS, "fl oz", 2.95735295625e-5},
+		{EnGB, "fluid ounces", 2.84130625e-5},
+		{EnGB, "short tons", 907.18474},
+		{EnUS, "long_ton", 1016.0469088},
+		{EnUS, "imp_gallon", 0.00454609},
+		{EnGB, "us_gallon", 0.003785411784},
+		{EnGB, "US fl oz", 2.95735295625e-5},
+		{EnUS, "metric_ton", 1000},
+		{EnGB, "tonnes", 1000},
+		{EnGB, "tons per day", 1016.0469088 / 86400},
+		{EnGB, "gallons/min", 0.00454609 / 60},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.locale)+" "+tt.unit, func(t *testing.T) {
+			info, err := tt.locale.Resolve(tt.unit)
+			if err != nil {
+				t.Fatalf("Resolve(%q) error: %v", tt.unit, err)
+			}
+			if math.Abs(info.ToBase-tt.wantToBase) > tt.wantToBase*1e-9 {
+				t.Errorf("Resolve(%q).ToBase = %g, want %g", tt.unit, info.ToBase, tt.wantToBase)
+			}
+		})
+	}
+}
+
+func TestParseLocale(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Locale
+	}{
+		{"", EnUS},
+		{"en-US", EnUS},
+		{"en_gb", EnGB},
+		{"EN-GB", EnGB},
+	}
+	for _, tt := range tests {
+		got, err := ParseLocale(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("ParseLocale(%q) = %q, %v, want %q", tt.in, got, err, tt.want)
+		}
+	}
+	if _, err := ParseLocale("fr-FR"); err == nil {
+		t.Error("ParseLocale(fr-FR) succeeded, want an error")
+	}
+}
+
+func TestLocaleLabel(t *testing.T) {
+	tests := []struct {
+		locale Locale
+		unit   string
+		value  float64
+		want   string
+	}{
+		{EnUS, "tons", 40, "tons"},
+		{EnGB, "tons", 40, "tons"},
+		{EnGB, "long_ton", 40, "tons"},
+		{EnGB, "long_ton", 1, "ton"},
+		{EnUS, "long_ton", 40, "long tons"},
+		{EnGB, "short ton", 1, "short ton"},
+		{EnGB, "us_gallon", 3, "US gallons"},
+		{EnUS, "imp_gallon", 3, "imperial gallons"},
+		{EnUS, "US gal", 3, "gallons"},
+		{EnGB, "short_ton per day", 40, "short tons per day"},
+		{EnGB, "us_gallon/min", 5, "US gallons/min"},
+		{EnGB, "kg", 40, "kg"},
+		{EnGB, "furlongs", 40, "furlongs"},
+	}
+	for _, tt := range tests {
+		if got := tt.locale.Label(tt.unit, tt.value); got != tt.want {
+			t.Errorf("%s.Label(%q, %g) = %q, want %q", tt.locale, tt.unit, tt.value, got, tt.want)
+		}
+	}
+}
+
+func TestLocaleIsRate(t *testing.T) {
+	for _, l := range []Locale{EnUS, EnGB} {
+		if !l.IsRate("tons per day") || !l.IsRate("gallons/min") {
+			t.Errorf("%s.IsRate() = false for a rate of a locale-dependent unit", l)
+		}
+		if l.IsRate("tons") || l.IsRate("km/h") {
+			t.Errorf("%s.IsRate() = true for a unit that is not a rate", l)
+		}
+	}
+}
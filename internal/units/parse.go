@@ -0,0 +1,437 @@
+package units
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// prefixSet selects which SI prefixes a unit's symbols and names accept.
+type prefixSet int
+
+const (
+	noPrefixes       prefixSet = iota
+	allPrefixes                // "nm", "km", "Gm"
+	positivePrefixes           // "kt", "Mt", "GWh"; no "mt"
+	dataPrefixes               // positive and binary: "kB", "KB", "MiB"
+	yearPrefixes               // only "ka", "Ma" and "Ga"; "Pa" is pascals
+	secondPrefixes             // "ks" and "ms" down to "fs"; "as" is a word
+)
+
+type prefix struct {
+	symbols  []string // case-sensitive
+	names    []string // lowercase
+	factor   float64
+	dataOnly bool // only valid on bits and bytes
+}
+
+// siPrefixes are tried in order, so "da" must precede "d" and each binary
+// prefix is tried after its SI counterpart has failed ("MiB").
+var siPrefixes = []prefix{
+	{[]string{"Q"}, []string{"quetta"}, 1e30, false},
+	{[]string{"R"}, []string{"ronna"}, 1e27, false},
+	{[]string{"Y"}, []string{"yotta"}, 1e24, false},
+	{[]string{"Z"}, []string{"zetta"}, 1e21, false},
+	{[]string{"E"}, []string{"exa"}, 1e18, false},
+	{[]string{"P"}, []string{"peta"}, 1e15, false},
+	{[]string{"T"}, []string{"tera"}, 1e12, false},
+	{[]string{"G"}, []string{"giga"}, 1e9, false},
+	{[]string{"M"}, []string{"mega"}, 1e6, false},
+	{[]string{"k"}, []string{"kilo"}, 1e3, false},
+	{[]string{"h"}, []string{"hecto"}, 1e2, false},
+	{[]string{"da"}, []string{"deca", "deka"}, 1e1, false},
+	{[]string{"d"}, []string{"deci"}, 1e-1, false},
+	{[]string{"c"}, []string{"centi"}, 1e-2, false},
+	{[]string{"m"}, []string{"milli"}, 1e-3, false},
+	{[]string{"µ", "μ", "u"}, []string{"micro"}, 1e-6, false},
+	{[]string{"n"}, []string{"nano"}, 1e-9, false},
+	{[]string{"p"}, []string{"pico"}, 1e-12, false},
+	{[]string{"f"}, []string{"femto"}, 1e-15, false},
+	{[]string{"a"}, []string{"atto"}, 1e-18, false},
+	{[]string{"z"}, []string{"zepto"}, 1e-21, false},
+	{[]string{"y"}, []string{"yocto"}, 1e-24, false},
+	{[]string{"r"}, []string{"ronto"}, 1e-27, false},
+	{[]string{"q"}, []string{"quecto"}, 1e-30, false},
+
+	// Binary prefixes, plus the customary "K" in "KB".
+	{[]string{"Ki"}, []string{"kibi"}, 1 << 10, true},
+	{[]string{"Mi"}, []string{"mebi"}, 1 << 20, true},
+	{[]string{"Gi"}, []string{"gibi"}, 1 << 30, true},
+	{[]string{"Ti"}, []string{"tebi"}, 1 << 40, true},
+	{[]string{"Pi"}, []string{"pebi"}, 1 << 50, true},
+	{[]string{"Ei"}, []string{"exbi"}, 1 << 60, true},
+	{[]string{"Zi"}, []string{"zebi"}, 1 << 70, true},
+	{[]string{"Yi"}, []string{"yobi"}, 1 << 80, true},
+	{[]string{"K"}, nil, 1e3, true},
+}
+
+func (s prefixSet) allows(p prefix) bool {
+	switch s {
+	case allPrefixes:
+		return !p.dataOnly
+	case positivePrefixes:
+		return p.factor > 1 && !p.dataOnly
+	case dataPrefixes:
+		return p.factor > 1
+	case yearPrefixes:
+		return !p.dataOnly && (p.factor == 1e3 || p.factor == 1e6 || p.factor == 1e9)
+	case secondPrefixes:
+		return !p.dataOnly && (p.factor == 1e3 || p.factor < 1 && p.factor >= 1e-15)
+	default:
+		return false
+	}
+}
+
+// spelling is one way of writing a unit, with whether it accepts a prefix.
+type spelling struct {
+	def        *unitDef
+	prefixable bool
+}
+
+var (
+	// tablesMu guards the lookup tables, which SetRates adds to while
+	// units may be resolved on other goroutines.
+	tablesMu sync.RWMutex
+	bySymbol = make(map[string]spelling) // symbols and aliases, case-sensitive
+	byName   = make(map[string]spelling) // names and plurals, lowercase
+)
+
+func init() {
+	for i := range unitDefs {
+		register(&unitDefs[i])
+	}
+}
+
+// register adds every spelling of d to the lookup tables, replacing any
+// earlier unit spelled the same way. Outside init, the caller holds
+// tablesMu.
+func register(d *unitDef) {
+	prefixable := d.prefixes != noPrefixes
+	for _, s := range d.symbols {
+		bySymbol[s] = spelling{d, prefixable}
+	}
+	for _, a := range d.aliases {
+		bySymbol[a] = spelling{d, false}
+	}
+	for _, n := range d.names {
+		n = strings.ToLower(n)
+		byName[n] = spelling{d, prefixable}
+		if len(d.plurals) == 0 {
+			// An irregular plural replaces the regular one ("foots").
+			byName[n+"s"] = spelling{d, prefixable}
+		}
+	}
+	for _, p := range d.plurals {
+		byName[strings.ToLower(p)] = spelling{d, prefixable}
+	}
+}
+
+// parse resolves unit by trying, in order: an exact symbol or alias, a
+// prefixed symbol, a (prefixed) name, an alias in any case ("MPH"), a symbol
+// with a trailing plural "s" ("kms", "hrs"), and finally powers ("km²") and
+// rates ("tons per day", "Mbps"). Symbols are never case-folded: "MM" is not
+// "mm", and "Ms" is not "ms".
+func (l Locale) parse(unit string) (UnitInfo, bool) {
+	unit = strings.Join(strings.Fields(unit), " ")
+	if unit == "" {
+		return UnitInfo{}, false
+	}
+	if v := variantOf(unit); v != nil {
+		return l.parse(v.units[l])
+	}
+	if info, ok := parseSymbol(unit); ok {
+		return info, true
+	}
+	lower := strings.ToLower(unit)
+	if info, ok := parseName(lower); ok {
+		return info, true
+	}
+	if lower != unit {
+		if info, ok := parseAlias(lower); ok {
+			return info, true
+		}
+	}
+	if trimmed, ok := strings.CutSuffix(unit, "s"); ok && len(trimmed) > 1 {
+		if info, ok := parseSymbol(trimmed); ok {
+			return info, true
+		}
+	}
+	if info, ok := l.parsePower(unit); ok {
+		return info, true
+	}
+	if num, ok := strings.CutSuffix(unit, "ps"); ok {
+		// Data rates per second: "Mbps" is "Mb/s".
+		if info, ok := parseSymbol(num); ok && info.Dimension == "data" {
+			return l.parseRate(num + "/s")
+		}
+	}
+	return l.parseRate(unit)
+}
+
+// powerDimensions are the dimensions of a length raised to a power.
+var powerDimensions = map[int]string{2: "area", 3: "volume"}
+
+// powerWords spell an exponent before or after a length unit.
+var (
+	powerPrefixes = map[string]int{"square": 2, "sq": 2, "sq.": 2, "cubic": 3, "cu": 3, "cu.": 3}
+	powerSuffixes = map[string]int{"squared": 2, "cubed": 3}
+	powerMarks    = []struct {
+		mark string
+		n    int
+	}{{"^2", 2}, {"²", 2}, {"2", 2}, {"^3", 3}, {"³", 3}, {"3", 3}}
+)
+
+// splitPower splits an area or volume written as a length unit and an
+// exponent, in any of "km^2", "km²", "km2", "square kilometers", "sq km" or
+// "kilometers squared". join puts a (corrected) base back into the same
+// notation.
+func splitPower(unit string) (base string, n int, join func(string) string, ok bool) {
+	unit = strings.Join(strings.Fields(unit), " ")
+	if word, rest, found := strings.Cut(unit, " "); found {
+		if n, ok := powerPrefixes[strings.ToLower(word)]; ok {
+			return rest, n, func(b string) string { return word + " " + b }, true
+		}
+	}
+	if i := strings.LastIndex(unit, " "); i > 0 {
+		rest, word := unit[:i], unit[i+1:]
+		if n, ok := powerSuffixes[strings.ToLower(word)]; ok {
+			return rest, n, func(b string) string { return b + " " + word }, true
+		}
+	}
+	for _, m := range powerMarks {
+		if rest, found := strings.CutSuffix(unit, m.mark); found && rest != "" {
+			return strings.TrimSpace(rest), m.n, func(b string) string { return b + m.mark }, true
+		}
+	}
+	return "", 0, nil, false
+}
+
+// parsePower resolves any length unit squared or cubed to an area or volume.
+func (l Locale) parsePower(unit string) (UnitInfo, bool) {
+	base, n, _, ok := splitPower(unit)
+	if !ok {
+		return UnitInfo{}, false
+	}
+	info, ok := l.parse(base)
+	if !ok || info.Scale != Linear || (info.Dimension != "length" && info.Dimension != "distance") {
+		return UnitInfo{}, false
+	}
+	return UnitInfo{Dimension: powerDimensions[n], ToBase: math.Pow(info.ToBase, float64(n))}, true
+}
+
+// rateDimensions maps a numerator's dimension to the dimension of its rate
+// where one exists: a length per time is a speed, energy per time a power.
+var rateDimensions = map[string]string{
+	"length":   "speed",
+	"distance": "speed",
+	"energy":   "power",
+}
+
+// countNounRe matches a plain noun such as "emails" or "cars", which counts
+// things when used as the numerator of a rate.
+var countNounRe = regexp.MustCompile(`^\pL[\pL' -]*$`)
+
+// SplitRate splits a rate such as "tons per day" or "kg/s" into its
+// numerator and the time it is per. Explicit units like "km/h" are not split.
+func SplitRate(unit string) (num, per string, ok bool) {
+	unit = strings.Join(strings.Fields(unit), " ")
+	if i := strings.LastIndex(strings.ToLower(unit), " per "); i > 0 {
+		return unit[:i], unit[i+len(" per "):], true
+	}
+	if i := strings.LastIndex(unit, "/"); i > 0 && i < len(unit)-1 {
+		return strings.TrimSpace(unit[:i]), strings.TrimSpace(unit[i+1:]), true
+	}
+	return "", "", false
+}
+
+// parseRate resolves "<unit> per <time>" and "<unit>/<time>". Lengths and
+// energies per time become speeds and powers; anything else is a Rate in the
+// numerator's dimension. A numerator that is not a unit but a plain noun
+// ("emails per second") counts things, unless it looks like a typo.
+func (l Locale) parseRate(unit string) (UnitInfo, bool) {
+	num, per, ok := SplitRate(unit)
+	if !ok {
+		return UnitInfo{}, false
+	}
+	time, ok := l.parse(per)
+	if !ok || time.Dimension != "duration" || time.Scale != Linear {
+		return UnitInfo{}, false
+	}
+
+	info, ok := l.parse(num)
+	switch {
+	case !ok && countNounRe.MatchString(num) && l.suggest(num) == "":
+		info = UnitInfo{Dimension: "count", ToBase: 1}
+	case !ok || info.Rate || info.Scale != Linear:
+		return UnitInfo{}, false
+	}
+	if dim, ok := rateDimensions[info.Dimension]; ok {
+		return UnitInfo{Dimension: dim, ToBase: info.ToBase / time.ToBase}, true
+	}
+	switch info.Dimension {
+	case "duration", "speed", "power":
+		return UnitInfo{}, false
+	}
+	return UnitInfo{Dimension: info.Dimension, ToBase: info.ToBase / time.ToBase, Rate: true}, true
+}
+
+func parseSymbol(s string) (UnitInfo, bool) {
+	tablesMu.RLock()
+	defer tablesMu.RUnlock()
+	if sp, ok := bySymbol[s]; ok {
+		return sp.info(1), true
+	}
+	for _, p := range siPrefixes {
+		for _, ps := range p.symbols {
+			rest, ok := strings.CutPrefix(s, ps)
+			if !ok {
+				continue
+			}
+			if sp, ok := bySymbol[rest]; ok && sp.prefixable && sp.def.prefixes.allows(p) {
+				return sp.info(p.factor), true
+			}
+		}
+	}
+	return UnitInfo{}, false
+}
+
+// parseAlias resolves s only if it is spelled exactly like a unit that
+// takes no prefix.
+func parseAlias(s string) (UnitInfo, bool) {
+	tablesMu.RLock()
+	defer tablesMu.RUnlock()
+	if sp, ok := bySymbol[s]; ok && !sp.prefixable {
+		return sp.info(1), true
+	}
+	return UnitInfo{}, false
+}
+
+func parseName(s string) (UnitInfo, bool) {
+	tablesMu.RLock()
+	defer tablesMu.RUnlock()
+	if sp, ok := byName[s]; ok {
+		return sp.info(1), true
+	}
+	for _, p := range siPrefixes {
+		for _, pn := range p.names {
+			rest, ok := strings.CutPrefix(s, pn)
+			if !ok {
+				continue
+			}
+			if sp, ok := byName[rest]; ok && sp.prefixable && sp.def.prefixes.allows(p) {
+				return sp.info(p.factor), true
+			}
+		}
+	}
+	return UnitInfo{}, false
+}
+
+func (sp spelling) info(factor float64) UnitInfo {
+	d := sp.def
+	return UnitInfo{
+		Dimension: d.dimension,
+		ToBase:    factor * d.toBase,
+		Scale:     d.scale(),
+		Offset:    d.offset,
+		Step:      d.step,
+	}
+}
+
+// suggest returns the known spelling closest to unit, or "" if nothing is
+// close enough to be a plausible typo. It never suggests unit itself.
+func (l Locale) suggest(unit string) string {
+	if base, _, join, ok := splitPower(unit); ok {
+		// "square kilometrs"
+		if s := l.suggest(base); s != "" {
+			return join(s)
+		}
+	}
+	if num, _, ok := SplitRate(unit); ok {
+		// Suggest a fix for the misspelled side: "tonz per day". When that
+		// side is fine, the rate itself is what is wrong: "years per second".
+		if _, known := l.parse(num); known {
+			return ""
+		}
+		if s := l.suggest(num); s != "" {
+			return strings.Replace(strings.Join(strings.Fields(unit), " "), num, s, 1)
+		}
+	}
+	normal := strings.Join(strings.Fields(unit), " ")
+	lower := strings.ToLower(normal)
+	if lower != normal {
+		// A symbol in the wrong case: "MM" for "mm".
+		if _, ok := parseSymbol(lower); ok {
+			return lower
+		}
+	}
+	n := len([]rune(lower))
+	if n < 3 {
+		return ""
+	}
+	maxDist := 1
+	if n >= 8 {
+		maxDist = 2
+	}
+
+	best, bestDist := "", maxDist+1
+	for _, s := range spellings() {
+		d := levenshtein(lower, strings.ToLower(s))
+		if d < bestDist {
+			best, bestDist = s, d
+		}
+	}
+	if best == normal {
+		return ""
+	}
+	return best
+}
+
+// spellings lists every symbol, alias and name, plus each prefixed name and
+// the locale-dependent spellings, sorted so suggestions are deterministic.
+func spellings() []string {
+	tablesMu.RLock()
+	defer tablesMu.RUnlock()
+	var out []string
+	for s := range bySymbol {
+		out = append(out, s)
+	}
+	for _, v := range variants {
+		out = append(out, v.spellings...)
+	}
+	for name, sp := range byName {
+		out = append(out, name)
+		if !sp.prefixable {
+			continue
+		}
+		for _, p := range siPrefixes {
+			if sp.def.prefixes.allows(p) && len(p.names) > 0 {
+				out = append(out, p.names[0]+name)
+			}
+		}
+	}
+	sort.Strings(out)
+	return out
+}
+
+func levenshtein(a, b string) int {
+	ar, br := []rune(a), []rune(b)
+	prev := make([]int, len(br)+1)
+	cur := make([]int, len(br)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ar); i++ {
+		cur[0] = i
+		for j := 1; j <= len(br); j++ {
+			cost := 1
+			if ar[i-1] == br[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(br)]
+}
@@ -0,0 +1,74 @@
+package units
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Quantity is a number with an optional unit, as parsed by ParseQuantity.
+// Unit is left as written; pass it to Resolve or Convert.
+type Quantity struct {
+	Value float64
+	Unit  string
+}
+
+var scaleWords = map[string]float64{
+	"thousand": 1e3,
+	"million":  1e6,
+	"billion":  1e9,
+	"trillion": 1e12,
+}
+
+// numberRe matches a leading number with optional thousands separators,
+// fraction and exponent: "4,500,000", "3.2", ".5", "1.5e9".
+var numberRe = regexp.MustCompile(`^[+-]?(?:\d{1,3}(?:,\d{3})+|\d+)?(?:\.\d+)?(?:[eE][+-]?\d+)?`)
+
+// ParseQuantity parses text such as "3.2 million km", "4,500,000 tons",
+// "1.5e9 lbs" or "5km". The number may use thousands separators and
+// scientific notation and be followed by a scale word (thousand, million,
+// billion, trillion); whatever follows is the unit. A currency symbol may
+// instead precede the number ("$3.2 billion"), in which case it is the unit,
+// or the numerator of a rate ("$5 million per year").
+func ParseQuantity(s string) (Quantity, error) {
+	s = strings.TrimSpace(s)
+	var symbol string
+	for _, sym := range currencySymbols {
+		if rest, ok := strings.CutPrefix(s, sym); ok {
+			symbol, s = sym, strings.TrimSpace(rest)
+			break
+		}
+	}
+	num := numberRe.FindString(s)
+	if !strings.ContainsAny(num, "0123456789") {
+		return Quantity{}, fmt.Errorf("%q does not start with a number", s)
+	}
+	tail := s[len(num):]
+	value, err := strconv.ParseFloat(strings.ReplaceAll(num, ",", ""), 64)
+	if err != nil || strings.IndexAny(tail, "0123456789,.") == 0 {
+		return Quantity{}, fmt.Errorf("%q is not a valid number", s)
+	}
+
+	rest := strings.Fields(tail)
+	if len(rest) > 0 {
+		if scale, ok := scaleWords[strings.ToLower(rest[0])]; ok {
+			value *= scale
+			rest = rest[1:]
+		}
+	}
+	if symbol != "" {
+		// "$5 million per year" and "$20/hr" are rates of money.
+		unit := strings.Join(rest, " ")
+		switch {
+		case unit == "":
+			return Quantity{Value: value, Unit: symbol}, nil
+		case strings.HasPrefix(unit, "/"):
+			return Quantity{Value: value, Unit: symbol + unit}, nil
+		case strings.HasPrefix(strings.ToLower(unit), "per "):
+			return Quantity{Value: value, Unit: symbol + " " + unit}, nil
+		}
+		return Quantity{}, fmt.Errorf("%q has both a currency symbol and a unit", symbol+s)
+	}
+	return Quantity{Value: value, Unit: strings.Join(rest, " ")}, nil
+}
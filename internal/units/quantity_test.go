@@ -0,0 +1,55 @@
+package units
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseQuantity(t *testing.T) {
+	tests := []struct {
+		in        string
+		wantValue float64
+		wantUnit  string
+	}{
+		{"500", 500, ""},
+		{"3.2 million km", 3.2e6, "km"},
+		{"4,500,000 tons", 4.5e6, "tons"},
+		{"2.5 billion years", 2.5e9, "years"},
+		{"1.5e9 lbs", 1.5e9, "lbs"},
+		{"5km", 5, "km"},
+		{"3.2million", 3.2e6, ""},
+		{"2 Trillion", 2e12, ""},
+		{"-40", -40, ""},
+		{".5 light years", 0.5, "light years"},
+		{"  12  thousand   feet ", 12000, "feet"},
+		{"$3.2 billion", 3.2e9, "$"},
+		{"US$ 500", 500, "US$"},
+		{"€20", 20, "€"},
+		{"$5 million per year", 5e6, "$ per year"},
+		{"$20/hr", 20, "$/hr"},
+		{"2.5 million emails per second", 2.5e6, "emails per second"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			q, err := ParseQuantity(tt.in)
+			if err != nil {
+				t.Fatalf("ParseQuantity(%q) error: %v", tt.in, err)
+			}
+			if math.Abs(q.Value-tt.wantValue) > math.Abs(tt.wantValue)*1e-12 {
+				t.Errorf("ParseQuantity(%q).Value = %g, want %g", tt.in, q.Value, tt.wantValue)
+			}
+			if q.Unit != tt.wantUnit {
+				t.Errorf("ParseQuantity(%q).Unit = %q, want %q", tt.in, q.Unit, tt.wantUnit)
+			}
+		})
+	}
+}
+
+func TestParseQuantityInvalid(t *testing.T) {
+	for _, in := range []string{"", "km", "abc", "3,5 km", "1.2.3", "1,00", "$", "$5 km"} {
+		if _, err := ParseQuantity(in); err == nil {
+			t.Errorf("ParseQuantity(%q) should return error", in)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package units
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// System is a family of units a quantity can be restated in for display.
+type System int
+
+const (
+	AsWritten System = iota // keep the unit the quantity was given in
+	Auto                    // the given unit's own system, at a readable scale
+	Metric
+	Imperial // US customary or imperial, as the locale reads "ton" and "gallon"
+)
+
+// ParseSystem parses "auto", "metric", "imperial" or "" (as written).
+func ParseSystem(s string) (System, error) {
+	switch strings.ToLower(s) {
+	case "":
+		return AsWritten, nil
+	case "auto":
+		return Auto, nil
+	case "metric", "si":
+		return Metric, nil
+	case "imperial", "us":
+		return Imperial, nil
+	default:
+		return AsWritten, fmt.Errorf("unknown unit system %q (want auto, metric or imperial)", s)
+	}
+}
+
+// displayUnit is a unit quantities are restated in, spelled for one and for
+// any other amount.
+type displayUnit struct {
+	one, many string
+}
+
+// same spells a unit the same way whatever the amount.
+func same(s string) displayUnit { return displayUnit{s, s} }
+
+var (
+	durationUnits   = []displayUnit{same("s"), same("min"), {"hour", "hours"}, {"day", "days"}, {"year", "years"}}
+	energyUnits     = []displayUnit{same("J"), same("kJ"), same("MJ"), same("GJ"), same("TJ"), same("PJ")}
+	powerUnits      = []displayUnit{same("W"), same("kW"), same("MW"), same("GW"), same("TW")}
+	dataUnits       = []displayUnit{same("B"), same("kB"), same("MB"), same("GB"), same("TB"), same("PB")}
+	metricLengths   = []displayUnit{same("mm"), same("cm"), same("m"), same("km")}
+	imperialLengths = []displayUnit{same("in"), same("ft"), same("mi")}
+)
+
+// displayUnits are the units each system restates a dimension in, smallest
+// first. Locale-dependent units are spelled unambiguously here and relabelled
+// for the locale. Temperature is handled by restateTemperature.
+var displayUnits = map[System]map[string][]displayUnit{
+	Metric: {
+		"length":   metricLengths,
+		"distance": metricLengths,
+		"weight":   {same("mg"), same("g"), same("kg"), same("t")},
+		"volume":   {same("mL"), same("L"), same("m³")},
+		"area":     {same("cm²"), same("m²"), same("ha"), same("km²")},
+		"speed":    {same("km/h")},
+		"duration": durationUnits,
+		"energy":   energyUnits,
+		"power":    powerUnits,
+		"data":     dataUnits,
+	},
+	Imperial: {
+		"length":   imperialLengths,
+		"distance": imperialLengths,
+		"weight":   {same("oz"), same("lb"), {"short ton", "short tons"}},
+		"volume":   {{"US fluid ounce", "US fluid ounces"}, {"US gallon", "US gallons"}},
+		"area":     {same("sq ft"), same("ac"), same("sq mi")},
+		"speed":    {same("mph")},
+		"duration": durationUnits,
+		"energy":   energyUnits,
+		"power":    powerUnits,
+		"data":     dataUnits,
+	},
+}
+
+// localUnits replaces the US spellings in displayUnits for other locales.
+var localUnits = map[Locale]map[string]displayUnit{
+	EnGB: {
+		"short ton":      {"long ton", "long tons"},
+		"US fluid ounce": {"imperial fluid ounce", "imperial fluid ounces"},
+		"US gallon":      {"imperial gallon", "imperial gallons"},
+	},
+}
+
+// ladder returns the units s restates dimension in for l's readers.
+func (l Locale) ladder(s System, dimension string) []displayUnit {
+	units := displayUnits[s][dimension]
+	local, ok := localUnits[l]
+	if !ok {
+		return units
+	}
+	out := make([]displayUnit, len(units))
+	for i, u := range units {
+		if lu, ok := local[u.one]; ok {
+			u = lu
+		}
+		out[i] = u
+	}
+	return out
+}
+
+// Restate returns value in unit as l's readers should see it in system s:
+// "0.003 km" is "3 m" with Auto, "5 km" is "3.1 mi" with Imperial. Auto keeps
+// the unit's system (metric, imperial, or one shared by both such as
+// seconds) and picks the largest unit the value is at least 1 of; units
+// outside every system, such as light years, are kept. Logarithmic units,
+// money and counts are kept too, and a rate restates its numerator. The
+// unit is labelled for the locale either way, as by Label.
+func (l Locale) Restate(value float64, unit string, s System) (float64, string, error) {
+	info, err := l.Resolve(unit)
+	if err != nil {
+		return 0, "", err
+	}
+	if s == AsWritten || info.Scale == Logarithmic {
+		return value, l.Label(unit, value), nil
+	}
+	if info.Rate {
+		num, _, _ := SplitRate(unit)
+		v, u, err := l.Restate(value, num, s)
+		if err != nil {
+			// A numerator such as "emails" has no unit to restate.
+			return value, l.Label(unit, value), nil
+		}
+		return v, u + strings.TrimPrefix(unit, num), nil
+	}
+	if info.Dimension == "temperature" {
+		return l.restateTemperature(value, unit, info, s)
+	}
+
+	if s == Auto {
+		s = l.systemOf(info)
+		if s == AsWritten {
+			return value, l.Label(unit, value), nil
+		}
+	}
+	units := l.ladder(s, info.Dimension)
+	if len(units) == 0 {
+		return value, l.Label(unit, value), nil
+	}
+
+	base := info.Base(value)
+	best, bestValue := units[0], base/l.toBase(units[0])
+	for _, u := range units[1:] {
+		v := base / l.toBase(u)
+		if math.Abs(v) < 1 {
+			break
+		}
+		best, bestValue = u, v
+	}
+	bestValue = tidy(bestValue)
+	name := best.many
+	if bestValue == 1 {
+		name = best.one
+	}
+	return bestValue, l.Label(name, bestValue), nil
+}
+
+// systemOf returns the system whose display units include info, or
+// AsWritten if none does.
+func (l Locale) systemOf(info UnitInfo) System {
+	for _, s := range []System{Metric, Imperial} {
+		for _, u := range l.ladder(s, info.Dimension) {
+			if other, ok := l.parse(u.one); ok && sameUnit(info, other) {
+				return s
+			}
+		}
+	}
+	return AsWritten
+}
+
+// restateTemperature restates a temperature in °C for Metric and °F for
+// Imperial. Kelvin counts as metric, and Auto keeps the unit.
+func (l Locale) restateTemperature(value float64, unit string, info UnitInfo, s System) (float64, string, error) {
+	target := ""
+	switch s {
+	case Metric:
+		if info.Offset != 0 || info.ToBase != 1 {
+			target = "°C"
+		}
+	case Imperial:
+		target = "°F"
+	}
+	if target == "" {
+		return value, unit, nil
+	}
+	to, _ := l.parse(target)
+	return tidy(info.Base(value)/to.ToBase - to.Offset), target, nil
+}
+
+func (l Locale) toBase(u displayUnit) float64 {
+	info, _ := l.parse(u.one)
+	return info.ToBase
+}
+
+// sameUnit reports whether a and b are the same unit, allowing for rounding
+// in prefixed and derived units.
+func sameUnit(a, b UnitInfo) bool {
+	return a.Dimension == b.Dimension && a.Scale == b.Scale && a.Offset == b.Offset &&
+		math.Abs(a.ToBase-b.ToBase) <= 1e-9*math.Abs(a.ToBase)
+}
+
+// tidy drops floating-point noise such as 2.9999999999999996 from a
+// converted value.
+func tidy(v float64) float64 {
+	t, _ := strconv.ParseFloat(strconv.FormatFloat(v, 'g', 12, 64), 64)
+	return t
+}
@@ -0,0 +1,58 @@
+package units
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRestate(t *testing.T) {
+	tests := []struct {
+		locale    Locale
+		value     float64
+		unit      string
+		system    System
+		wantValue float64
+		wantUnit  string
+	}{
+		{EnUS, 0.003, "km", AsWritten, 0.003, "km"},
+		{EnUS, 0.003, "km", Auto, 3, "m"},
+		{EnUS, 1, "km", Auto, 1, "km"},
+		{EnUS, 0.5, "lb", Auto, 8, "oz"},
+		{EnUS, 90, "minutes", Auto, 1.5, "hours"},
+		{EnUS, 1500, "MB", Auto, 1.5, "GB"},
+		{EnUS, 4, "ly", Auto, 4, "ly"},
+		{EnUS, 3, "US gal", Auto, 3, "gallons"},
+		{EnGB, 3, "US gal", Auto, 3, "US gallons"},
+		{EnUS, 5, "km", Imperial, 3.10685596119, "mi"},
+		{EnUS, 40, "tons", Metric, 36.2873896, "t"},
+		{EnUS, 40000, "kg", Imperial, 44.092452437, "tons"},
+		{EnGB, 40000, "kg", Imperial, 39.3682611044, "tons"},
+		{EnUS, 100, "°C", Imperial, 212, "°F"},
+		{EnUS, 300, "K", Metric, 300, "K"},
+		{EnUS, 40, "tons per day", Metric, 36.2873896, "t per day"},
+		{EnUS, 3, "emails per second", Metric, 3, "emails per second"},
+		{EnUS, 7, "magnitude", Metric, 7, "magnitude"},
+		{EnUS, 20, "$", Metric, 20, "$"},
+	}
+	for _, tt := range tests {
+		v, u, err := tt.locale.Restate(tt.value, tt.unit, tt.system)
+		if err != nil {
+			t.Errorf("%s.Restate(%g, %q, %d) error: %v", tt.locale, tt.value, tt.unit, tt.system, err)
+			continue
+		}
+		if math.Abs(v-tt.wantValue) > 1e-9*tt.wantValue || u != tt.wantUnit {
+			t.Errorf("%s.Restate(%g, %q, %d) = %g %s, want %g %s", tt.locale, tt.value, tt.unit, tt.system, v, u, tt.wantValue, tt.wantUnit)
+		}
+	}
+}
+
+func TestParseSystem(t *testing.T) {
+	for in, want := range map[string]System{"": AsWritten, "auto": Auto, "Metric": Metric, "imperial": Imperial} {
+		if got, err := ParseSystem(in); err != nil || got != want {
+			t.Errorf("ParseSystem(%q) = %d, %v, want %d", in, got, err, want)
+		}
+	}
+	if _, err := ParseSystem("cubits"); err == nil {
+		t.Error("ParseSystem(cubits) succeeded, want an error")
+	}
+}
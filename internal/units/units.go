@@ -1,68 +1,217 @@
 package units
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"math"
+)
 
+// Scale is how a unit's values map onto its dimension's base unit.
+type Scale int
+
+const (
+	Linear      Scale = iota // base = v × ToBase
+	Affine                   // base = (v + Offset) × ToBase, e.g. °C → K
+	Logarithmic              // base = ToBase × 10^(v / Step), e.g. Richter magnitude → J
+)
+
+// UnitInfo describes a resolved unit. Most units are Linear and only need
+// ToBase; use Base rather than multiplying so the others convert correctly.
 type UnitInfo struct {
 	Dimension string
 	ToBase    float64
+	Scale     Scale
+	Offset    float64 // Affine only
+	Step      float64 // Logarithmic only: the change in value per factor of 10
+	Rate      bool    // "<unit> per <time>": ToBase gives base units per second
+}
+
+// Base converts v in this unit to the dimension's base unit.
+func (u UnitInfo) Base(v float64) float64 {
+	switch u.Scale {
+	case Affine:
+		return (v + u.Offset) * u.ToBase
+	case Logarithmic:
+		return u.ToBase * math.Pow(10, v/u.Step)
+	default:
+		return v * u.ToBase
+	}
+}
+
+// Difference converts a difference between two base values into this unit,
+// e.g. 10 K into 18 °F. It is meaningless for Logarithmic units.
+func (u UnitInfo) Difference(base float64) float64 {
+	return base / u.ToBase
 }
 
-var unitTable = map[string]UnitInfo{
+// unitDef describes one unit and every way of spelling it. Symbols are
+// case-sensitive and take the SI prefixes allowed by prefixes ("m" → "km",
+// "µm"); aliases are case-sensitive spellings that take no prefix. Names are
+// matched case-insensitively, with or without a prefix name ("kilometers"),
+// in the singular or plural. Plurals lists irregular plurals; otherwise a
+// name's plural is the name plus "s".
+type unitDef struct {
+	dimension string
+	toBase    float64
+	symbols   []string
+	aliases   []string
+	names     []string
+	plurals   []string
+	prefixes  prefixSet
+	offset    float64 // makes the unit Affine
+	step      float64 // makes the unit Logarithmic
+}
+
+func (d *unitDef) scale() Scale {
+	switch {
+	case d.step != 0:
+		return Logarithmic
+	case d.offset != 0:
+		return Affine
+	default:
+		return Linear
+	}
+}
+
+var unitDefs = []unitDef{
 	// length (base: meters)
-	"m":      {"length", 1},
-	"meters": {"length", 1},
-	"km":     {"length", 1000},
-	"ft":     {"length", 0.3048},
-	"feet":   {"length", 0.3048},
-	"mi":     {"length", 1609.344},
-	"miles":  {"length", 1609.344},
+	{dimension: "length", toBase: 1, symbols: []string{"m"}, names: []string{"meter", "metre"}, prefixes: allPrefixes},
+	{dimension: "length", toBase: 0.0254, aliases: []string{"in"}, names: []string{"inch"}, plurals: []string{"inches"}},
+	{dimension: "length", toBase: 0.3048, aliases: []string{"ft"}, names: []string{"foot"}, plurals: []string{"feet"}},
+	{dimension: "length", toBase: 0.9144, aliases: []string{"yd"}, names: []string{"yard"}},
+	{dimension: "length", toBase: 1609.344, aliases: []string{"mi"}, names: []string{"mile"}},
+	{dimension: "length", toBase: 1852, aliases: []string{"nmi"}, names: []string{"nautical mile"}},
 
 	// weight (base: kg)
-	"kg":   {"weight", 1},
-	"g":    {"weight", 0.001},
-	"lbs":  {"weight", 0.453592},
-	"tons": {"weight", 907.185},
+	{dimension: "weight", toBase: 0.001, symbols: []string{"g"}, names: []string{"gram", "gramme"}, prefixes: allPrefixes},
+	{dimension: "weight", toBase: 1000, symbols: []string{"t"}, aliases: []string{"metric_ton"}, names: []string{"tonne", "metric ton"}, prefixes: positivePrefixes},
+	{dimension: "weight", toBase: 0.0283495, aliases: []string{"oz"}, names: []string{"ounce"}},
+	{dimension: "weight", toBase: 0.453592, aliases: []string{"lb", "lbs"}, names: []string{"pound"}},
+	{dimension: "weight", toBase: 6.35029, aliases: []string{"st"}, names: []string{"stone"}},
+	{dimension: "weight", toBase: 907.18474, aliases: []string{"short_ton"}, names: []string{"short ton", "US ton"}},
+	{dimension: "weight", toBase: 1016.0469088, aliases: []string{"long_ton"}, names: []string{"long ton", "imperial ton"}},
 
 	// volume (base: m³)
-	"m3":      {"volume", 1},
-	"liters":  {"volume", 0.001},
-	"gallons": {"volume", 0.003785},
+	{dimension: "volume", toBase: 1, aliases: []string{"m3", "m³"}, names: []string{"cubic meter", "cubic metre"}},
+	{dimension: "volume", toBase: 0.001, symbols: []string{"L", "l"}, names: []string{"liter", "litre"}, prefixes: allPrefixes},
+	{dimension: "volume", toBase: 0.003785411784, aliases: []string{"us_gallon", "US gal"}, names: []string{"US gallon"}},
+	{dimension: "volume", toBase: 0.00454609, aliases: []string{"imp_gallon", "imp gal"}, names: []string{"imperial gallon", "UK gallon"}},
+	{dimension: "volume", toBase: 2.95735295625e-5, aliases: []string{"us_fl_oz", "US fl oz"}, names: []string{"US fluid ounce"}},
+	{dimension: "volume", toBase: 2.84130625e-5, aliases: []string{"imp_fl_oz", "imp fl oz"}, names: []string{"imperial fluid ounce", "UK fluid ounce"}},
 
 	// area (base: m²)
-	"m2":       {"area", 1},
-	"acres":    {"area", 4046.86},
-	"hectares": {"area", 10000},
+	{dimension: "area", toBase: 1, aliases: []string{"m2", "m²"}, names: []string{"square meter", "square metre"}},
+	{dimension: "area", toBase: 4046.86, aliases: []string{"ac"}, names: []string{"acre"}},
+	{dimension: "area", toBase: 10000, aliases: []string{"ha"}, names: []string{"hectare"}},
 
 	// distance (base: meters) — same physical unit as length but separate dimension
-	"au": {"distance", 149597870700},
-	"ly": {"distance", 9.4607e15},
+	{dimension: "distance", toBase: 149597870700, aliases: []string{"au", "AU"}, names: []string{"astronomical unit"}},
+	{dimension: "distance", toBase: 9.4607e15, aliases: []string{"ly"}, names: []string{"light year", "light-year", "lightyear"}},
+	{dimension: "distance", toBase: 3.0857e16, symbols: []string{"pc"}, names: []string{"parsec"}, prefixes: positivePrefixes},
+
+	// speed (base: m/s)
+	{dimension: "speed", toBase: 1, symbols: []string{"m/s"}, aliases: []string{"mps"}, names: []string{"meter per second", "metre per second"}, plurals: []string{"meters per second", "metres per second"}, prefixes: allPrefixes},
+	{dimension: "speed", toBase: 1 / 3.6, aliases: []string{"km/h", "km/hr", "kph", "kmh"}, names: []string{"kilometer per hour", "kilometre per hour"}, plurals: []string{"kilometers per hour", "kilometres per hour"}},
+	{dimension: "speed", toBase: 0.44704, aliases: []string{"mph", "mi/h"}, names: []string{"mile per hour"}, plurals: []string{"miles per hour"}},
+	{dimension: "speed", toBase: 1852.0 / 3600, aliases: []string{"kn", "kts"}, names: []string{"knot"}},
+	{dimension: "speed", toBase: 343, aliases: []string{"Mach"}, names: []string{"mach"}},
+	{dimension: "speed", toBase: 299792458, aliases: []string{"c"}, names: []string{"speed of light"}, plurals: []string{"speed of light"}},
+
+	// energy (base: joules)
+	{dimension: "energy", toBase: 1, symbols: []string{"J"}, names: []string{"joule"}, prefixes: allPrefixes},
+	{dimension: "energy", toBase: 3600, symbols: []string{"Wh"}, names: []string{"watt hour", "watt-hour"}, prefixes: positivePrefixes},
+	{dimension: "energy", toBase: 4.184, symbols: []string{"cal"}, names: []string{"calorie"}, prefixes: positivePrefixes},
+	{dimension: "energy", toBase: 4184, aliases: []string{"Cal"}},
+	{dimension: "energy", toBase: 1055.06, aliases: []string{"BTU", "Btu"}, names: []string{"british thermal unit"}},
+	{dimension: "energy", toBase: 4.184e9, aliases: []string{"t TNT"}, names: []string{"ton of TNT", "tonne of TNT"}, plurals: []string{"tons of TNT", "tonnes of TNT"}},
+	{dimension: "energy", toBase: 4.184e12, aliases: []string{"kt TNT"}, names: []string{"kiloton", "kiloton of TNT"}, plurals: []string{"kilotons", "kilotons of TNT"}},
+	{dimension: "energy", toBase: 4.184e15, aliases: []string{"Mt TNT"}, names: []string{"megaton", "megaton of TNT"}, plurals: []string{"megatons", "megatons of TNT"}},
+	// Earthquake magnitude, as the energy released: log10(E/J) = 1.5 M + 4.8.
+	{dimension: "energy", toBase: math.Pow(10, 4.8), step: 1 / 1.5, aliases: []string{"Mw"}, names: []string{"magnitude", "richter", "on the richter scale"}, plurals: []string{"magnitude", "richter", "on the richter scale"}},
+
+	// power (base: watts)
+	{dimension: "power", toBase: 1, symbols: []string{"W"}, names: []string{"watt"}, prefixes: allPrefixes},
+	{dimension: "power", toBase: 745.7, aliases: []string{"hp"}, names: []string{"horsepower"}, plurals: []string{"horsepower"}},
+
+	// data (base: bytes)
+	{dimension: "data", toBase: 1, symbols: []string{"B"}, names: []string{"byte"}, prefixes: dataPrefixes},
+	{dimension: "data", toBase: 0.125, symbols: []string{"b", "bit"}, names: []string{"bit"}, prefixes: dataPrefixes},
+
+	// temperature (base: kelvin)
+	{dimension: "temperature", toBase: 1, symbols: []string{"K"}, names: []string{"kelvin"}, prefixes: allPrefixes},
+	{dimension: "temperature", toBase: 1, offset: 273.15, aliases: []string{"°C", "℃", "C", "degC"}, names: []string{"degree celsius", "degree C", "celsius", "centigrade"}, plurals: []string{"degrees celsius", "degrees C", "celsius", "centigrade"}},
+	{dimension: "temperature", toBase: 5.0 / 9, offset: 459.67, aliases: []string{"°F", "℉", "F", "degF"}, names: []string{"degree fahrenheit", "degree F", "fahrenheit"}, plurals: []string{"degrees fahrenheit", "degrees F", "fahrenheit"}},
+
+	// money (base: US dollars); other currencies are added by SetRates
+	{dimension: "money", toBase: 1, aliases: []string{"USD", "US$", "$"}, names: []string{"dollar", "US dollar"}},
 
 	// duration (base: seconds)
-	"s":       {"duration", 1},
-	"sec":     {"duration", 1},
-	"seconds": {"duration", 1},
-	"min":     {"duration", 60},
-	"minutes": {"duration", 60},
-	"hr":      {"duration", 3600},
-	"hours":   {"duration", 3600},
-	"days":    {"duration", 86400},
-	"years":   {"duration", 31557600},
+	{dimension: "duration", toBase: 1, symbols: []string{"s"}, aliases: []string{"sec", "secs"}, names: []string{"second"}, prefixes: secondPrefixes},
+	{dimension: "duration", toBase: 60, aliases: []string{"min", "mins"}, names: []string{"minute"}},
+	{dimension: "duration", toBase: 3600, aliases: []string{"h", "hr", "hrs"}, names: []string{"hour"}},
+	{dimension: "duration", toBase: 86400, aliases: []string{"d"}, names: []string{"day"}},
+	{dimension: "duration", toBase: 604800, aliases: []string{"wk", "wks"}, names: []string{"week"}},
+	{dimension: "duration", toBase: 31557600, symbols: []string{"a"}, aliases: []string{"y", "yr", "yrs"}, names: []string{"year"}, prefixes: yearPrefixes},
+	{dimension: "duration", toBase: 315576000, names: []string{"decade"}},
+	{dimension: "duration", toBase: 3155760000, names: []string{"century"}, plurals: []string{"centuries"}},
+	{dimension: "duration", toBase: 31557600000, names: []string{"millennium"}, plurals: []string{"millennia", "millenniums"}},
 }
 
+// ErrUnknownUnit is returned (wrapped) when a unit cannot be resolved.
+var ErrUnknownUnit = errors.New("unknown unit")
+
+// Resolve parses a unit expression such as "km", "micrometers", "µs" or
+// "Light Years" in the default locale. Unknown units return an error
+// wrapping ErrUnknownUnit that suggests the closest known spelling, if any.
 func Resolve(unit string) (UnitInfo, error) {
-	info, ok := unitTable[unit]
+	return DefaultLocale.Resolve(unit)
+}
+
+// Resolve is Resolve with locale-dependent spellings such as "tons" and
+// "gallons" read as l means them.
+func (l Locale) Resolve(unit string) (UnitInfo, error) {
+	info, ok := l.parse(unit)
 	if !ok {
-		return UnitInfo{}, fmt.Errorf("unknown unit: %q", unit)
+		if code, ok := currencyCode(unit); ok {
+			return UnitInfo{}, fmt.Errorf("%w: %q (no exchange rate for %s has been loaded)", ErrUnknownUnit, unit, code)
+		}
+		if s := l.suggest(unit); s != "" {
+			return UnitInfo{}, fmt.Errorf("%w: %q (did you mean %q?)", ErrUnknownUnit, unit, s)
+		}
+		return UnitInfo{}, fmt.Errorf("%w: %q", ErrUnknownUnit, unit)
 	}
 	return info, nil
 }
 
+// Convert converts value in unit to its dimension's base unit in the default
+// locale, returning the base value and the dimension.
 func Convert(value float64, unit string) (float64, string, error) {
-	info, err := Resolve(unit)
+	return DefaultLocale.Convert(value, unit)
+}
+
+// Convert is Convert in locale l.
+func (l Locale) Convert(value float64, unit string) (float64, string, error) {
+	info, err := l.Resolve(unit)
 	if err != nil {
 		return 0, "", err
 	}
-	return value * info.ToBase, info.Dimension, nil
+	base := info.Base(value)
+	if info.Dimension == "temperature" && base < 0 {
+		return 0, "", fmt.Errorf("%g %s is below absolute zero", value, unit)
+	}
+	return base, info.Dimension, nil
+}
+
+// IsRate reports whether unit is a rate such as "tons per day", which
+// Convert returns in base units per second, in the default locale.
+func IsRate(unit string) bool {
+	return DefaultLocale.IsRate(unit)
+}
+
+// IsRate is IsRate with unit read as l means it.
+func (l Locale) IsRate(unit string) bool {
+	info, err := l.Resolve(unit)
+	return err == nil && info.Rate
 }
 
 // ToMeters converts a value in the given unit to meters.
@@ -75,5 +224,5 @@ func ToMeters(value float64, unit string) (float64, error) {
 	if info.Dimension != "length" {
 		return 0, fmt.Errorf("unit %q is not a length unit", unit)
 	}
-	return value * info.ToBase, nil
+	return info.Base(value), nil
 }
@@ -1,7 +1,10 @@
 package units
 
 import (
+	"errors"
+	"fmt"
 	"math"
+	"strings"
 	"testing"
 )
 
@@ -21,10 +24,10 @@ func TestResolve(t *testing.T) {
 		{"kg", "weight", 1},
 		{"g", "weight", 0.001},
 		{"lbs", "weight", 0.453592},
-		{"tons", "weight", 907.185},
+		{"tons", "weight", 907.18474},
 		{"m3", "volume", 1},
 		{"liters", "volume", 0.001},
-		{"gallons", "volume", 0.003785},
+		{"gallons", "volume", 0.003785411784},
 		{"m2", "area", 1},
 		{"acres", "area", 4046.86},
 		{"hectares", "area", 10000},
@@ -114,3 +117,433 @@ func TestToMetersBackwardCompat(t *testing.T) {
 		t.Errorf("ToMeters(5, km) = %f, want 5000", got)
 	}
 }
+
+func TestResolveUnknownUnitIsErrUnknownUnit(t *testing.T) {
+	_, err := Resolve("cubits")
+	if !errors.Is(err, ErrUnknownUnit) {
+		t.Errorf("Resolve(cubits) error = %v, want ErrUnknownUnit", err)
+	}
+}
+
+func TestResolvePrefixes(t *testing.T) {
+	tests := []struct {
+		unit       string
+		wantDim    string
+		wantToBase float64
+	}{
+		{"cm", "length", 0.01},
+		{"mm", "length", 0.001},
+		{"nm", "length", 1e-9},
+		{"µm", "length", 1e-6},
+		{"um", "length", 1e-6},
+		{"Gm", "length", 1e9},
+		{"Mm", "length", 1e6},
+		{"dam", "length", 10},
+		{"mg", "weight", 1e-6},
+		{"kt", "weight", 1e6},
+		{"mL", "volume", 1e-6},
+		{"ms", "duration", 0.001},
+		{"µs", "duration", 1e-6},
+		{"Ma", "duration", 31557600e6},
+		{"Ga", "duration", 31557600e9},
+		{"ka", "duration", 31557600e3},
+		{"fs", "duration", 1e-15},
+		{"ks", "duration", 1e3},
+		{"kpc", "distance", 3.0857e19},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.unit, func(t *testing.T) {
+			info, err := Resolve(tt.unit)
+			if err != nil {
+				t.Fatalf("Resolve(%q) error: %v", tt.unit, err)
+			}
+			if info.Dimension != tt.wantDim {
+				t.Errorf("Resolve(%q).Dimension = %q, want %q", tt.unit, info.Dimension, tt.wantDim)
+			}
+			if math.Abs(info.ToBase-tt.wantToBase) > tt.wantToBase*1e-9 {
+				t.Errorf("Resolve(%q).ToBase = %g, want %g", tt.unit, info.ToBase, tt.wantToBase)
+			}
+		})
+	}
+}
+
+func TestResolveNames(t *testing.T) {
+	tests := []struct {
+		unit       string
+		wantDim    string
+		wantToBase float64
+	}{
+		{"micrometers", "length", 1e-6},
+		{"Kilometres", "length", 1000},
+		{"meter", "length", 1},
+		{"foot", "length", 0.3048},
+		{"inches", "length", 0.0254},
+		{"milligram", "weight", 1e-6},
+		{"megatonnes", "weight", 1e9},
+		{"Light Years", "distance", 9.4607e15},
+		{"microseconds", "duration", 1e-6},
+		{"centuries", "duration", 3155760000},
+		{"kms", "length", 1000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.unit, func(t *testing.T) {
+			info, err := Resolve(tt.unit)
+			if err != nil {
+				t.Fatalf("Resolve(%q) error: %v", tt.unit, err)
+			}
+			if info.Dimension != tt.wantDim {
+				t.Errorf("Resolve(%q).Dimension = %q, want %q", tt.unit, info.Dimension, tt.wantDim)
+			}
+			if math.Abs(info.ToBase-tt.wantToBase) > tt.wantToBase*1e-9 {
+				t.Errorf("Resolve(%q).ToBase = %g, want %g", tt.unit, info.ToBase, tt.wantToBase)
+			}
+		})
+	}
+}
+
+func TestResolveDisallowedPrefix(t *testing.T) {
+	// Tonnes only take positive prefixes; feet take none. Years take only
+	// ka, Ma and Ga, so "Pa" is not a peta-year, and "as" is not an
+	// attosecond.
+	for _, unit := range []string{"mt", "kft", "kilofeet", "Pa", "Ta", "petayears", "as"} {
+		if _, err := Resolve(unit); !errors.Is(err, ErrUnknownUnit) {
+			t.Errorf("Resolve(%q) error = %v, want ErrUnknownUnit", unit, err)
+		}
+	}
+}
+
+func TestResolveSymbolCase(t *testing.T) {
+	// Prefixed symbols are case-sensitive: a wrong case is a different
+	// magnitude, so it is an error with a suggestion rather than a guess.
+	tests := []struct{ unit, want string }{
+		{"Ms", "ms"},
+		{"MM", "mm"},
+		{"Cs", "cs"},
+		{"KM", "km"},
+	}
+	for _, tt := range tests {
+		_, err := Resolve(tt.unit)
+		if !errors.Is(err, ErrUnknownUnit) || !strings.Contains(err.Error(), fmt.Sprintf("did you mean %q?", tt.want)) {
+			t.Errorf("Resolve(%q) error = %v, want ErrUnknownUnit suggesting %q", tt.unit, err, tt.want)
+		}
+	}
+
+	// Aliases take no prefix, so any case is safe.
+	for _, unit := range []string{"MPH", "Hrs", "LBS"} {
+		if _, err := Resolve(unit); err != nil {
+			t.Errorf("Resolve(%q) error: %v", unit, err)
+		}
+	}
+}
+
+func TestResolveSuggestion(t *testing.T) {
+	_, err := Resolve("kilomters")
+	if err == nil || !strings.Contains(err.Error(), `did you mean "kilometers"?`) {
+		t.Errorf("Resolve(kilomters) error = %v, want a kilometers suggestion", err)
+	}
+	_, err = Resolve("cubits")
+	if err == nil || strings.Contains(err.Error(), "did you mean") {
+		t.Errorf("Resolve(cubits) error = %v, want no suggestion", err)
+	}
+}
+
+func TestResolveSpeed(t *testing.T) {
+	tests := []struct {
+		unit       string
+		wantToBase float64
+	}{
+		{"m/s", 1},
+		{"km/s", 1000},
+		{"kilometers per second", 1000},
+		{"km/h", 1 / 3.6},
+		{"mph", 0.44704},
+		{"miles per hour", 0.44704},
+		{"knots", 0.514444},
+		{"kts", 0.514444},
+		{"Mach", 343},
+		{"c", 299792458},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.unit, func(t *testing.T) {
+			info, err := Resolve(tt.unit)
+			if err != nil {
+				t.Fatalf("Resolve(%q) error: %v", tt.unit, err)
+			}
+			if info.Dimension != "speed" {
+				t.Errorf("Resolve(%q).Dimension = %q, want speed", tt.unit, info.Dimension)
+			}
+			if math.Abs(info.ToBase-tt.wantToBase) > tt.wantToBase*1e-6 {
+				t.Errorf("Resolve(%q).ToBase = %g, want %g", tt.unit, info.ToBase, tt.wantToBase)
+			}
+		})
+	}
+}
+
+func TestResolveEnergyAndPower(t *testing.T) {
+	tests := []struct {
+		unit       string
+		wantDim    string
+		wantToBase float64
+	}{
+		{"J", "energy", 1},
+		{"kJ", "energy", 1000},
+		{"kWh", "energy", 3.6e6},
+		{"MWh", "energy", 3.6e9},
+		{"TWh", "energy", 3.6e15},
+		{"kilowatt hours", "energy", 3.6e6},
+		{"calories", "energy", 4.184},
+		{"kcal", "energy", 4184},
+		{"BTU", "energy", 1055.06},
+		{"tons of TNT", "energy", 4.184e9},
+		{"kilotons", "energy", 4.184e12},
+		{"megatons of TNT", "energy", 4.184e15},
+		{"Mt TNT", "energy", 4.184e15},
+		{"W", "power", 1},
+		{"kW", "power", 1000},
+		{"GW", "power", 1e9},
+		{"horsepower", "power", 745.7},
+		{"hp", "power", 745.7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.unit, func(t *testing.T) {
+			info, err := Resolve(tt.unit)
+			if err != nil {
+				t.Fatalf("Resolve(%q) error: %v", tt.unit, err)
+			}
+			if info.Dimension != tt.wantDim {
+				t.Errorf("Resolve(%q).Dimension = %q, want %q", tt.unit, info.Dimension, tt.wantDim)
+			}
+			if math.Abs(info.ToBase-tt.wantToBase) > tt.wantToBase*1e-9 {
+				t.Errorf("Resolve(%q).ToBase = %g, want %g", tt.unit, info.ToBase, tt.wantToBase)
+			}
+		})
+	}
+}
+
+func TestResolveMegatonnesStayWeight(t *testing.T) {
+	// "megatons" is TNT-equivalent energy; "megatonnes" and "Mt" are mass.
+	for _, unit := range []string{"megatonnes", "Mt"} {
+		info, err := Resolve(unit)
+		if err != nil {
+			t.Fatalf("Resolve(%q) error: %v", unit, err)
+		}
+		if info.Dimension != "weight" {
+			t.Errorf("Resolve(%q).Dimension = %q, want weight", unit, info.Dimension)
+		}
+	}
+}
+
+func TestResolveData(t *testing.T) {
+	tests := []struct {
+		unit       string
+		wantToBase float64
+	}{
+		{"B", 1},
+		{"bytes", 1},
+		{"kB", 1e3},
+		{"KB", 1e3},
+		{"MB", 1e6},
+		{"TB", 1e12},
+		{"ZB", 1e21},
+		{"KiB", 1024},
+		{"MiB", 1 << 20},
+		{"ZiB", 1 << 70},
+		{"gibibytes", 1 << 30},
+		{"bits", 0.125},
+		{"Mb", 125000},
+		{"Gbit", 1.25e8},
+		{"megabits", 125000},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.unit, func(t *testing.T) {
+			info, err := Resolve(tt.unit)
+			if err != nil {
+				t.Fatalf("Resolve(%q) error: %v", tt.unit, err)
+			}
+			if info.Dimension != "data" {
+				t.Errorf("Resolve(%q).Dimension = %q, want data", tt.unit, info.Dimension)
+			}
+			if math.Abs(info.ToBase-tt.wantToBase) > tt.wantToBase*1e-9 {
+				t.Errorf("Resolve(%q).ToBase = %g, want %g", tt.unit, info.ToBase, tt.wantToBase)
+			}
+		})
+	}
+}
+
+func TestResolveBinaryPrefixOnlyOnData(t *testing.T) {
+	for _, unit := range []string{"Kim", "mB", "kibimeters"} {
+		if _, err := Resolve(unit); !errors.Is(err, ErrUnknownUnit) {
+			t.Errorf("Resolve(%q) error = %v, want ErrUnknownUnit", unit, err)
+		}
+	}
+}
+
+func TestConvertTemperature(t *testing.T) {
+	tests := []struct {
+		value float64
+		unit  string
+		want  float64
+	}{
+		{0, "K", 0},
+		{300, "mK", 0.3},
+		{100, "°C", 373.15},
+		{-40, "degrees Celsius", 233.15},
+		{32, "°F", 273.15},
+		{-40, "fahrenheit", 233.15},
+	}
+	for _, tt := range tests {
+		got, dim, err := Convert(tt.value, tt.unit)
+		if err != nil {
+			t.Fatalf("Convert(%g, %q) error: %v", tt.value, tt.unit, err)
+		}
+		if dim != "temperature" || math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("Convert(%g, %q) = %g %s, want %g temperature", tt.value, tt.unit, got, dim, tt.want)
+		}
+	}
+
+	if _, _, err := Convert(-300, "°C"); err == nil {
+		t.Error("Convert(-300, °C) should fail below absolute zero")
+	}
+}
+
+func TestTemperatureDifference(t *testing.T) {
+	info, err := Resolve("°F")
+	if err != nil {
+		t.Fatalf("Resolve(°F) error: %v", err)
+	}
+	if got := info.Difference(10); math.Abs(got-18) > 1e-9 {
+		t.Errorf("10 K difference in °F = %g, want 18", got)
+	}
+}
+
+func TestConvertRichterMagnitude(t *testing.T) {
+	// Each whole step releases about 31.6 times as much energy.
+	m6, _, err := Convert(6, "magnitude")
+	if err != nil {
+		t.Fatalf("Convert(6, magnitude) error: %v", err)
+	}
+	m7, dim, err := Convert(7, "on the Richter scale")
+	if err != nil {
+		t.Fatalf("Convert(7, on the Richter scale) error: %v", err)
+	}
+	if dim != "energy" {
+		t.Errorf("dimension = %q, want energy", dim)
+	}
+	if want := math.Pow(10, 1.5*7+4.8); math.Abs(m7-want) > want*1e-9 {
+		t.Errorf("magnitude 7 = %g J, want %g", m7, want)
+	}
+	if ratio := m7 / m6; math.Abs(ratio-math.Pow(10, 1.5)) > 1e-9 {
+		t.Errorf("magnitude 7 / magnitude 6 = %g, want 10^1.5", ratio)
+	}
+}
+
+func TestResolveRate(t *testing.T) {
+	tests := []struct {
+		unit          string
+		wantDimension string
+		wantToBase    float64
+		wantRate      bool
+	}{
+		{"tons per day", "weight", 907.18474 / 86400, true},
+		{"kg/s", "weight", 1, true},
+		{"L/min", "volume", 0.001 / 60, true},
+		{"MB/s", "data", 1e6, true},
+		{"Mbps", "data", 1.25e5, true},
+		{"Gbps", "data", 1.25e8, true},
+		{"bps", "data", 0.125, true},
+		{"$ per year", "money", 1 / 31557600.0, true},
+		{"emails per second", "count", 1, true},
+		{"cars/hr", "count", 1 / 3600.0, true},
+		// A length per time is a speed and an energy per time a power.
+		{"ft/s", "speed", 0.3048, false},
+		{"kilometers per hour", "speed", 1 / 3.6, false},
+		{"kWh/day", "power", 3.6e6 / 86400, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.unit, func(t *testing.T) {
+			info, err := Resolve(tt.unit)
+			if err != nil {
+				t.Fatalf("Resolve(%q) error: %v", tt.unit, err)
+			}
+			if info.Dimension != tt.wantDimension || info.Rate != tt.wantRate {
+				t.Errorf("Resolve(%q) = %+v, want %s with Rate %v", tt.unit, info, tt.wantDimension, tt.wantRate)
+			}
+			if math.Abs(info.ToBase-tt.wantToBase) > tt.wantToBase*1e-9 {
+				t.Errorf("Resolve(%q).ToBase = %g, want %g", tt.unit, info.ToBase, tt.wantToBase)
+			}
+		})
+	}
+}
+
+func TestResolveRateInvalid(t *testing.T) {
+	for _, unit := range []string{"m/s/s", "hours per day", "°C per hour", "tons per meter", "kg/"} {
+		if _, err := Resolve(unit); !errors.Is(err, ErrUnknownUnit) {
+			t.Errorf("Resolve(%q) error = %v, want ErrUnknownUnit", unit, err)
+		}
+	}
+
+	_, err := Resolve("tonz per day")
+	if err == nil || !strings.Contains(err.Error(), `did you mean "ton per day"?`) {
+		t.Errorf("Resolve(tonz per day) error = %v, want a suggestion", err)
+	}
+
+	// The numerator is fine; only the rate is not, so there is no typo to fix.
+	_, err = Resolve("years per second")
+	if !errors.Is(err, ErrUnknownUnit) || strings.Contains(err.Error(), "did you mean") {
+		t.Errorf("Resolve(years per second) error = %v, want no suggestion", err)
+	}
+}
+
+func TestResolvePowersOfLength(t *testing.T) {
+	tests := []struct {
+		unit          string
+		wantDimension string
+		wantToBase    float64
+	}{
+		{"km^2", "area", 1e6},
+		{"km²", "area", 1e6},
+		{"km2", "area", 1e6},
+		{"square kilometers", "area", 1e6},
+		{"kilometres squared", "area", 1e6},
+		{"sq mi", "area", 1609.344 * 1609.344},
+		{"sq. ft", "area", 0.3048 * 0.3048},
+		{"ft3", "volume", 0.3048 * 0.3048 * 0.3048},
+		{"ft³", "volume", 0.3048 * 0.3048 * 0.3048},
+		{"cubic feet", "volume", 0.3048 * 0.3048 * 0.3048},
+		{"cu yd", "volume", 0.9144 * 0.9144 * 0.9144},
+		{"cm^3", "volume", 1e-6},
+		{"cubic light years", "volume", 9.4607e15 * 9.4607e15 * 9.4607e15},
+	}
+	for _, tt := range tests {
+		t.Run(tt.unit, func(t *testing.T) {
+			info, err := Resolve(tt.unit)
+			if err != nil {
+				t.Fatalf("Resolve(%q) error: %v", tt.unit, err)
+			}
+			if info.Dimension != tt.wantDimension {
+				t.Errorf("Resolve(%q).Dimension = %q, want %q", tt.unit, info.Dimension, tt.wantDimension)
+			}
+			if math.Abs(info.ToBase-tt.wantToBase) > tt.wantToBase*1e-9 {
+				t.Errorf("Resolve(%q).ToBase = %g, want %g", tt.unit, info.ToBase, tt.wantToBase)
+			}
+		})
+	}
+}
+
+func TestResolvePowersOfNonLength(t *testing.T) {
+	for _, unit := range []string{"kg^2", "square seconds", "°C³", "cubic", "square"} {
+		if _, err := Resolve(unit); !errors.Is(err, ErrUnknownUnit) {
+			t.Errorf("Resolve(%q) error = %v, want ErrUnknownUnit", unit, err)
+		}
+	}
+
+	_, err := Resolve("square kilometrs")
+	if err == nil || !strings.Contains(err.Error(), `did you mean "square kilometers"?`) {
+		t.Errorf("Resolve(square kilometrs) error = %v, want a suggestion", err)
+	}
+}
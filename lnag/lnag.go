@@ -0,0 +1,486 @@
+// Package lnag generates analogies that put large and small numbers in terms
+// of familiar concepts, e.g. "500 m is about the length of 5 Soccer Fields."
+package lnag
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/creimer/lnag/internal/data"
+	"github.com/creimer/lnag/internal/formatter"
+	"github.com/creimer/lnag/internal/matcher"
+	"github.com/creimer/lnag/internal/units"
+)
+
+// Concept is a named thing with one or more known measurements.
+type Concept = data.Concept
+
+// Result is a single analogy. Concept is set for Analogize and AnalogizeTime
+// results and for AnalogizeCount in the count dimension. AnalogizeTime sets
+// Seconds, the time taken, instead of Ratio. Rates ("tons per day") set
+// Seconds too, to the window of time they name in Window. UnitItem and TargetItem are set for AnalogizeCount and
+// AnalogizeItem results. TargetDimension is set when the target is measured
+// in a different dimension than the unit item, e.g. a stack of items
+// reaching a distance. Temperatures are compared by Difference, the input
+// minus the concept in the displayed unit, rather than by Ratio.
+// DisplayValue and DisplayUnit are the input as the sentence restates it,
+// which WithUnitSystem may change from Value and Unit. Citations is set
+// with WithCitations.
+type Result struct {
+	Sentence        string     `json:"sentence"`
+	Dimension       string     `json:"dimension"`
+	TargetDimension string     `json:"target_dimension,omitempty"`
+	Ratio           float64    `json:"ratio"`
+	Difference      float64    `json:"difference,omitempty"`
+	Value           float64    `json:"value,omitempty"`
+	Unit            string     `json:"unit,omitempty"`
+	DisplayValue    float64    `json:"display_value,omitempty"`
+	DisplayUnit     string     `json:"display_unit,omitempty"`
+	Count           float64    `json:"count,omitempty"`
+	Seconds         float64    `json:"seconds,omitempty"`
+	Window          string     `json:"window,omitempty"`
+	Concept         *Concept   `json:"concept,omitempty"`
+	UnitItem        *Concept   `json:"unit_item,omitempty"`
+	TargetItem      *Concept   `json:"target_item,omitempty"`
+	Citations       []Citation `json:"citations,omitempty"`
+}
+
+// Citation says where the measurements of a concept in a Result come from.
+// Concepts without a source are cited too, so the gaps are visible.
+type Citation struct {
+	Concept string `json:"concept"`
+	Source  string `json:"source,omitempty"`
+	AsOf    string `json:"as_of,omitempty"`
+	Notes   string `json:"notes,omitempty"`
+}
+
+// String formats c for a reader: "Tour de France: letour.fr, as of 2024,
+// note: ~23 days".
+func (c Citation) String() string {
+	parts := []string{"no source"}
+	if c.Source != "" {
+		parts[0] = c.Source
+	}
+	if c.AsOf != "" {
+		parts = append(parts, "as of "+c.AsOf)
+	}
+	if c.Notes != "" {
+		parts = append(parts, "note: "+c.Notes)
+	}
+	return c.Concept + ": " + strings.Join(parts, ", ")
+}
+
+// cite returns citations for concepts if WithCitations is set.
+func (g *Generator) cite(concepts ...*Concept) []Citation {
+	if !g.cfg.citations {
+		return nil
+	}
+	var cs []Citation
+	for _, c := range concepts {
+		cs = append(cs, Citation{Concept: c.Name, Source: c.Source, AsOf: c.AsOf, Notes: c.Notes})
+	}
+	return cs
+}
+
+// ErrUnknownUnit is returned (wrapped) by Analogize when the unit is not recognized.
+var ErrUnknownUnit = units.ErrUnknownUnit
+
+// Quantity is a number with an optional unit, as parsed by ParseQuantity.
+type Quantity = units.Quantity
+
+// ParseQuantity parses text such as "3.2 million km", "4,500,000 tons" or
+// "1.5e9 lbs" into a value and the unit as written, which may be empty.
+func ParseQuantity(s string) (Quantity, error) {
+	return units.ParseQuantity(s)
+}
+
+// ErrUnknownConcept is returned (wrapped) by AnalogizeItem when no concept
+// matches the item name.
+var ErrUnknownConcept = data.ErrUnknownConcept
+
+// ErrTimeEmphasis is returned by AnalogizeTime and AnalogizeTimeTop when the
+// Generator has an emphasis: a time is never a fraction or a multiple.
+var ErrTimeEmphasis = matcher.ErrTimeEmphasis
+
+// Generator produces analogies from a concept library.
+type Generator struct {
+	base      *data.ConceptStore // before cfg.filter is applied
+	cfg       config
+	store     *data.ConceptStore
+	matchOpts []matcher.Option
+	unitTmpl  *template.Template
+	countTmpl *template.Template
+}
+
+var templateFuncs = template.FuncMap{
+	"humanizeRatio":  formatter.HumanizeRatio,
+	"humanizeNumber": formatter.HumanizeNumber,
+	"humanizeCount":  formatter.HumanizeNumber, // the name before humanizeNumber
+	"approxCount":    formatter.ApproxCount,
+}
+
+// LoadRatesFile makes the currencies in a JSON file of the form
+// {"usd_per_unit": {"EUR": 1.09}} usable as money units ("20 EUR", "€20").
+// Rates are never fetched over the network. They are process-wide, shared by
+// every Generator, and safe to load while Generators are in use.
+func LoadRatesFile(path string) error {
+	return units.LoadRatesFile(path)
+}
+
+// New loads the embedded concept library and returns a Generator.
+func New(opts ...Option) (*Generator, error) {
+	var cfg config
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	var storeOpts []data.StoreOption
+	if cfg.pricesFile != "" {
+		storeOpts = append(storeOpts, data.WithPricesFile(cfg.pricesFile))
+	}
+	if len(cfg.conceptPaths) > 0 {
+		storeOpts = append(storeOpts, data.WithConceptFiles(cfg.conceptPaths...))
+	}
+	store, err := data.NewConceptStore(storeOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("loading concepts: %w", err)
+	}
+	return newGenerator(store, config{}, opts)
+}
+
+// With returns a copy of g with opts applied on top of the options g was
+// created with. The concept library is not reloaded, so this is cheap enough
+// to call per request.
+func (g *Generator) With(opts ...Option) (*Generator, error) {
+	return newGenerator(g.base, g.cfg, opts)
+}
+
+func newGenerator(base *data.ConceptStore, cfg config, opts []Option) (*Generator, error) {
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	if cfg.locale == "" {
+		cfg.locale = units.DefaultLocale
+	}
+
+	g := &Generator{base: base, cfg: cfg, store: base}
+	if cfg.filter != nil {
+		g.store = base.Filter(cfg.filter)
+	}
+	if cfg.rng != nil {
+		g.matchOpts = append(g.matchOpts, matcher.WithRand(cfg.rng))
+	}
+	if cfg.bestOnly {
+		g.matchOpts = append(g.matchOpts, matcher.BestOnly())
+	}
+	if cfg.emphasis != EmphasisNone {
+		g.matchOpts = append(g.matchOpts, matcher.WithEmphasis(cfg.emphasis))
+	}
+
+	var err error
+	if cfg.unitTemplate != "" {
+		if g.unitTmpl, err = template.New("unit").Funcs(templateFuncs).Parse(cfg.unitTemplate); err != nil {
+			return nil, fmt.Errorf("parsing unit template: %w", err)
+		}
+	}
+	if cfg.countTemplate != "" {
+		if g.countTmpl, err = template.New("count").Funcs(templateFuncs).Parse(cfg.countTemplate); err != nil {
+			return nil, fmt.Errorf("parsing count template: %w", err)
+		}
+	}
+	return g, nil
+}
+
+// Analogize compares value, measured in unit, to a single concept. A rate
+// such as "tons per day" is scaled to a window of time over which it adds
+// up to a concept; Result.Window names the window.
+func (g *Generator) Analogize(value float64, unit string) (Result, error) {
+	baseValue, dimension, err := g.cfg.locale.Convert(value, unit)
+	if err != nil {
+		return Result{}, err
+	}
+	if g.cfg.locale.IsRate(unit) {
+		r, err := matcher.FindRateMatch(baseValue, dimension, g.store, g.matchOpts...)
+		if err != nil {
+			return Result{}, err
+		}
+		return g.rateResult(r, value, unit)
+	}
+	r, err := matcher.FindUnitMatch(baseValue, dimension, g.store, g.matchOpts...)
+	if err != nil {
+		return Result{}, err
+	}
+	return g.unitResult(r, value, unit)
+}
+
+// AnalogizeTop returns up to n distinct analogies for value in unit, best
+// first. If n <= 0 it returns every analogy Analogize could have picked.
+func (g *Generator) AnalogizeTop(value float64, unit string, n int) ([]Result, error) {
+	baseValue, dimension, err := g.cfg.locale.Convert(value, unit)
+	if err != nil {
+		return nil, err
+	}
+	if g.cfg.locale.IsRate(unit) {
+		matches, err := matcher.FindRateMatches(baseValue, dimension, g.store, n, g.matchOpts...)
+		if err != nil {
+			return nil, err
+		}
+		results := make([]Result, len(matches))
+		for i, r := range matches {
+			if results[i], err = g.rateResult(r, value, unit); err != nil {
+				return nil, err
+			}
+		}
+		return results, nil
+	}
+	matches, err := matcher.FindUnitMatches(baseValue, dimension, g.store, n, g.matchOpts...)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]Result, len(matches))
+	for i, r := range matches {
+		if results[i], err = g.unitResult(r, value, unit); err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// countDimension holds populations and tallies, which a number is compared
+// with directly rather than used to multiply an item's size.
+const countDimension = "count"
+
+// AnalogizeCount expresses count copies of one concept in terms of another
+// concept along the given dimension. If dimension is empty, every dimension
+// is tried and the one used is reported in Result.Dimension. The "count"
+// dimension instead compares count itself with populations and tallies:
+// "8,000,000 is about the population of New York City."
+func (g *Generator) AnalogizeCount(count float64, dimension string) (Result, error) {
+	if dimension == countDimension {
+		r, err := matcher.FindUnitMatch(count, dimension, g.store, g.matchOpts...)
+		if err != nil {
+			return Result{}, err
+		}
+		return g.unitResult(r, count, "")
+	}
+
+	var r matcher.DimensionResult
+	var err error
+	if dimension == "" {
+		r, err = matcher.FindAnyDimensionMatch(count, g.store, g.matchOpts...)
+	} else {
+		r, err = matcher.FindDimensionMatch(count, dimension, g.store, g.matchOpts...)
+	}
+	if err != nil {
+		return Result{}, err
+	}
+	return g.countResult(r)
+}
+
+// AnalogizeCountTop returns up to n distinct count analogies, best first.
+// If n <= 0 it returns every analogy AnalogizeCount could have picked.
+func (g *Generator) AnalogizeCountTop(count float64, dimension string, n int) ([]Result, error) {
+	if dimension == countDimension {
+		matches, err := matcher.FindUnitMatches(count, dimension, g.store, n, g.matchOpts...)
+		if err != nil {
+			return nil, err
+		}
+		results := make([]Result, len(matches))
+		for i, r := range matches {
+			if results[i], err = g.unitResult(r, count, ""); err != nil {
+				return nil, err
+			}
+		}
+		return results, nil
+	}
+
+	var matches []matcher.DimensionResult
+	var err error
+	if dimension == "" {
+		matches, err = matcher.FindAnyDimensionMatches(count, g.store, n, g.matchOpts...)
+	} else {
+		matches, err = matcher.FindDimensionMatches(count, dimension, g.store, n, g.matchOpts...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	results := make([]Result, len(matches))
+	for i, r := range matches {
+		if results[i], err = g.countResult(r); err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// AnalogizeItem expresses count copies of the named item in terms of another
+// concept. The item is looked up by name, so "iphone" finds "Smartphone
+// (iPhone 14)". If dimension is empty, every dimension the item has a
+// measurement for is tried; lengths, heights and widths are also compared
+// against distances ("stacked would reach the Moon").
+func (g *Generator) AnalogizeItem(count float64, item, dimension string) (Result, error) {
+	c, err := g.base.Lookup(item)
+	if err != nil {
+		return Result{}, err
+	}
+	r, err := matcher.FindItemMatch(count, *c, g.store, itemDimensions(dimension), g.matchOpts...)
+	if err != nil {
+		return Result{}, err
+	}
+	return g.countResult(r)
+}
+
+// AnalogizeItemTop returns up to n distinct item analogies, best first.
+// If n <= 0 it returns every analogy AnalogizeItem could have picked.
+func (g *Generator) AnalogizeItemTop(count float64, item, dimension string, n int) ([]Result, error) {
+	c, err := g.base.Lookup(item)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := matcher.FindItemMatches(count, *c, g.store, itemDimensions(dimension), n, g.matchOpts...)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]Result, len(matches))
+	for i, r := range matches {
+		if results[i], err = g.countResult(r); err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+// AnalogizeTime expresses a rate, such as a speed, as the time it takes to
+// get through a concept: "60 km/s would reach the Moon in about 2 hours."
+func (g *Generator) AnalogizeTime(value float64, unit string) (Result, error) {
+	baseValue, dimension, err := g.cfg.locale.Convert(value, unit)
+	if err != nil {
+		return Result{}, err
+	}
+	r, err := matcher.FindTimeMatch(baseValue, dimension, g.store, g.matchOpts...)
+	if err != nil {
+		return Result{}, err
+	}
+	return g.timeResult(r, value, unit)
+}
+
+// AnalogizeTimeTop returns up to n distinct time analogies, best first.
+// If n <= 0 it returns every analogy AnalogizeTime could have picked.
+func (g *Generator) AnalogizeTimeTop(value float64, unit string, n int) ([]Result, error) {
+	baseValue, dimension, err := g.cfg.locale.Convert(value, unit)
+	if err != nil {
+		return nil, err
+	}
+	matches, err := matcher.FindTimeMatches(baseValue, dimension, g.store, n, g.matchOpts...)
+	if err != nil {
+		return nil, err
+	}
+	results := make([]Result, len(matches))
+	for i, r := range matches {
+		if results[i], err = g.timeResult(r, value, unit); err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+func itemDimensions(dimension string) []string {
+	if dimension == "" {
+		return nil
+	}
+	return []string{dimension}
+}
+
+// display restates value in unit for the sentence, in the configured unit
+// system and locale.
+func (g *Generator) display(value float64, unit string) (float64, string) {
+	v, u, err := g.cfg.locale.Restate(value, unit, g.cfg.unitSystem)
+	if err != nil {
+		return value, unit
+	}
+	return v, u
+}
+
+func (g *Generator) unitResult(r matcher.UnitResult, value float64, unit string) (Result, error) {
+	dv, du := g.display(value, unit)
+	res := Result{
+		Sentence:     formatter.FormatUnitResult(r, dv, du),
+		Dimension:    r.Dimension,
+		Ratio:        r.Ratio,
+		Value:        value,
+		Unit:         unit,
+		DisplayValue: dv,
+		DisplayUnit:  du,
+		Concept:      &r.Concept,
+		Citations:    g.cite(&r.Concept),
+	}
+	if r.Difference != 0 {
+		if info, err := g.cfg.locale.Resolve(du); err == nil {
+			res.Difference = info.Difference(r.Difference)
+		}
+	}
+	return render(g.unitTmpl, res)
+}
+
+func (g *Generator) countResult(r matcher.DimensionResult) (Result, error) {
+	res := Result{
+		Sentence:        formatter.FormatDimensionResult(r),
+		Dimension:       r.Dimension,
+		TargetDimension: r.TargetDimension,
+		Ratio:           r.Ratio,
+		Count:           r.Count,
+		UnitItem:        &r.UnitItem,
+		TargetItem:      &r.TargetItem,
+		Citations:       g.cite(&r.UnitItem, &r.TargetItem),
+	}
+	return render(g.countTmpl, res)
+}
+
+func (g *Generator) timeResult(r matcher.TimeResult, value float64, unit string) (Result, error) {
+	dv, du := g.display(value, unit)
+	res := Result{
+		Sentence:        formatter.FormatTimeResult(r, dv, du),
+		Dimension:       r.Dimension,
+		TargetDimension: r.TargetDimension,
+		Value:           value,
+		Unit:            unit,
+		DisplayValue:    dv,
+		DisplayUnit:     du,
+		Seconds:         r.Seconds,
+		Concept:         &r.Concept,
+		Citations:       g.cite(&r.Concept),
+	}
+	return render(g.unitTmpl, res)
+}
+
+func (g *Generator) rateResult(r matcher.RateResult, value float64, unit string) (Result, error) {
+	dv, du := g.display(value, unit)
+	res := Result{
+		Sentence:     formatter.FormatRateResult(r, dv, du),
+		Dimension:    r.Dimension,
+		Ratio:        r.Ratio,
+		Value:        value,
+		Unit:         unit,
+		DisplayValue: dv,
+		DisplayUnit:  du,
+		Seconds:      r.Seconds,
+		Window:       r.Window,
+		Concept:      &r.Concept,
+		Citations:    g.cite(&r.Concept),
+	}
+	return render(g.unitTmpl, res)
+}
+
+// render replaces res.Sentence with the output of tmpl, if one is set.
+func render(tmpl *template.Template, res Result) (Result, error) {
+	if tmpl == nil {
+		return res, nil
+	}
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, res); err != nil {
+		return Result{}, fmt.Errorf("executing %s template: %w", tmpl.Name(), err)
+	}
+	res.Sentence = buf.String()
+	return res, nil
+}
@@ -0,0 +1,663 @@
+package lnag
+
+import (
+	"errors"
+	"math"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/creimer/lnag/internal/data"
+)
+
+func TestAnalogize(t *testing.T) {
+	g, err := New()
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	res, err := g.Analogize(500, "m")
+	if err != nil {
+		t.Fatalf("Analogize() error: %v", err)
+	}
+	if !strings.HasPrefix(res.Sentence, "500 m is") {
+		t.Errorf("sentence = %q, want prefix %q", res.Sentence, "500 m is")
+	}
+	if res.Concept == nil {
+		t.Error("concept should be set")
+	}
+	if res.Value != 500 || res.Unit != "m" {
+		t.Errorf("value, unit = %f, %q, want 500, m", res.Value, res.Unit)
+	}
+}
+
+func TestAnalogizeUnknownUnit(t *testing.T) {
+	g, err := New()
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	if _, err := g.Analogize(5, "cubits"); err == nil {
+		t.Error("expected error for unknown unit")
+	}
+}
+
+func TestAnalogizeCount(t *testing.T) {
+	g, err := New()
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	res, err := g.AnalogizeCount(2000, "weight")
+	if err != nil {
+		t.Fatalf("AnalogizeCount() error: %v", err)
+	}
+	if res.Dimension != "weight" {
+		t.Errorf("dimension = %q, want weight", res.Dimension)
+	}
+	if res.UnitItem == nil || res.TargetItem == nil {
+		t.Fatal("unit and target items should be set")
+	}
+	if res.Count != 2000 {
+		t.Errorf("count = %f, want 2000", res.Count)
+	}
+}
+
+func TestAnalogizeCountAnyDimension(t *testing.T) {
+	g, err := New()
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	dims := make(map[string]bool)
+	for _, d := range data.Dimensions() {
+		dims[d] = data.Additive(d)
+	}
+	for i := 0; i < 10; i++ {
+		res, err := g.AnalogizeCount(3000000, "")
+		if err != nil {
+			t.Fatalf("AnalogizeCount() error: %v", err)
+		}
+		if !dims[res.Dimension] {
+			t.Errorf("dimension = %q, want a known dimension", res.Dimension)
+		}
+	}
+}
+
+func TestAnalogizeTop(t *testing.T) {
+	g, err := New()
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	results, err := g.AnalogizeTop(500, "m", 5)
+	if err != nil {
+		t.Fatalf("AnalogizeTop() error: %v", err)
+	}
+	if len(results) != 5 {
+		t.Fatalf("got %d results, want 5", len(results))
+	}
+	seen := make(map[string]bool)
+	for _, res := range results {
+		if seen[res.Concept.Name] {
+			t.Errorf("duplicate concept %q", res.Concept.Name)
+		}
+		seen[res.Concept.Name] = true
+		if !strings.HasPrefix(res.Sentence, "500 m is") {
+			t.Errorf("sentence = %q, want prefix %q", res.Sentence, "500 m is")
+		}
+	}
+}
+
+func TestAnalogizeCountTop(t *testing.T) {
+	g, err := New()
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	results, err := g.AnalogizeCountTop(2000, "weight", 3)
+	if err != nil {
+		t.Fatalf("AnalogizeCountTop() error: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3", len(results))
+	}
+	for _, res := range results {
+		if res.UnitItem == nil || res.TargetItem == nil {
+			t.Error("unit and target items should be set")
+		}
+	}
+}
+
+func TestWithRandIsReproducible(t *testing.T) {
+	sentence := func() string {
+		g, err := New(WithRand(rand.New(rand.NewPCG(42, 0))))
+		if err != nil {
+			t.Fatalf("New() error: %v", err)
+		}
+		res, err := g.AnalogizeCount(3000000, "height")
+		if err != nil {
+			t.Fatalf("AnalogizeCount() error: %v", err)
+		}
+		return res.Sentence
+	}
+	first := sentence()
+	for i := 0; i < 5; i++ {
+		if got := sentence(); got != first {
+			t.Fatalf("same seed gave %q then %q", first, got)
+		}
+	}
+}
+
+func TestWithSeedIsReproducible(t *testing.T) {
+	var first string
+	for i := 0; i < 5; i++ {
+		g, err := New(WithSeed(7))
+		if err != nil {
+			t.Fatalf("New() error: %v", err)
+		}
+		res, err := g.Analogize(500, "m")
+		if err != nil {
+			t.Fatalf("Analogize() error: %v", err)
+		}
+		if i == 0 {
+			first = res.Sentence
+		} else if res.Sentence != first {
+			t.Fatalf("same seed gave %q then %q", first, res.Sentence)
+		}
+	}
+}
+
+func TestWithBestOnly(t *testing.T) {
+	g, err := New(WithBestOnly())
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	first, err := g.AnalogizeCount(3000000, "height")
+	if err != nil {
+		t.Fatalf("AnalogizeCount() error: %v", err)
+	}
+	for i := 0; i < 5; i++ {
+		res, err := g.AnalogizeCount(3000000, "height")
+		if err != nil {
+			t.Fatalf("AnalogizeCount() error: %v", err)
+		}
+		if res.Sentence != first.Sentence {
+			t.Fatalf("best-only gave %q then %q", first.Sentence, res.Sentence)
+		}
+	}
+}
+
+func TestWithFilter(t *testing.T) {
+	g, err := New(WithFilter(func(c Concept) bool { return c.Category == "Animal" }))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	for i := 0; i < 20; i++ {
+		res, err := g.Analogize(5000, "kg")
+		if err != nil {
+			t.Fatalf("Analogize() error: %v", err)
+		}
+		if res.Concept.Category != "Animal" {
+			t.Fatalf("concept %q has category %q, want Animal", res.Concept.Name, res.Concept.Category)
+		}
+	}
+}
+
+func TestWithTemplates(t *testing.T) {
+	g, err := New(WithTemplates(
+		"{{.Concept.Name}} x{{humanizeCount .Ratio}}",
+		"[{{.Dimension}}] {{.Sentence}}",
+	))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+
+	res, err := g.Analogize(500, "m")
+	if err != nil {
+		t.Fatalf("Analogize() error: %v", err)
+	}
+	want := res.Concept.Name + " x"
+	if !strings.HasPrefix(res.Sentence, want) {
+		t.Errorf("sentence = %q, want prefix %q", res.Sentence, want)
+	}
+
+	res, err = g.AnalogizeCount(2000, "weight")
+	if err != nil {
+		t.Fatalf("AnalogizeCount() error: %v", err)
+	}
+	if !strings.HasPrefix(res.Sentence, "[weight] 2,000 ") {
+		t.Errorf("sentence = %q, want prefix %q", res.Sentence, "[weight] 2,000 ")
+	}
+}
+
+func TestWithTemplatesParseError(t *testing.T) {
+	if _, err := New(WithTemplates("{{.Sentence", "")); err == nil {
+		t.Error("expected error for malformed template")
+	}
+}
+
+func TestWithEmphasis(t *testing.T) {
+	g, err := New()
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	small, err := g.With(WithEmphasis(EmphasisSmall))
+	if err != nil {
+		t.Fatalf("With() error: %v", err)
+	}
+	large, err := g.With(WithEmphasis(EmphasisLarge))
+	if err != nil {
+		t.Fatalf("With() error: %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		res, err := small.Analogize(50, "m")
+		if err != nil {
+			t.Fatalf("Analogize() error: %v", err)
+		}
+		if res.Ratio >= 1 {
+			t.Errorf("small emphasis gave ratio %f: %q", res.Ratio, res.Sentence)
+		}
+		if !strings.Contains(res.Sentence, "only about") {
+			t.Errorf("small emphasis sentence %q should contain %q", res.Sentence, "only about")
+		}
+
+		res, err = large.Analogize(50, "m")
+		if err != nil {
+			t.Fatalf("Analogize() error: %v", err)
+		}
+		if res.Ratio < 10 {
+			t.Errorf("large emphasis gave ratio %f: %q", res.Ratio, res.Sentence)
+		}
+	}
+}
+
+func TestWithKeepsFilter(t *testing.T) {
+	g, err := New(WithFilter(func(c Concept) bool { return c.Category == "Animal" }))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	g2, err := g.With(WithBestOnly())
+	if err != nil {
+		t.Fatalf("With() error: %v", err)
+	}
+	res, err := g2.Analogize(5000, "kg")
+	if err != nil {
+		t.Fatalf("Analogize() error: %v", err)
+	}
+	if res.Concept.Category != "Animal" {
+		t.Errorf("concept %q has category %q, want Animal", res.Concept.Name, res.Concept.Category)
+	}
+}
+
+func TestAnalogizeItem(t *testing.T) {
+	g, err := New()
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	res, err := g.AnalogizeItem(3000000, "iphone", "")
+	if err != nil {
+		t.Fatalf("AnalogizeItem() error: %v", err)
+	}
+	if res.UnitItem == nil || res.UnitItem.Name != "Smartphone (iPhone 14)" {
+		t.Fatalf("unit item = %v, want Smartphone (iPhone 14)", res.UnitItem)
+	}
+	if res.TargetItem.Name == res.UnitItem.Name {
+		t.Error("target should not be the item itself")
+	}
+
+	res, err = g.AnalogizeItem(3000000, "iphone", "weight")
+	if err != nil {
+		t.Fatalf("AnalogizeItem() error: %v", err)
+	}
+	if res.Dimension != "weight" {
+		t.Errorf("dimension = %q, want weight", res.Dimension)
+	}
+}
+
+func TestAnalogizeItemTop(t *testing.T) {
+	g, err := New()
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	results, err := g.AnalogizeItemTop(3000000, "iphone", "", 3)
+	if err != nil {
+		t.Fatalf("AnalogizeItemTop() error: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3", len(results))
+	}
+}
+
+func TestAnalogizeItemUnknown(t *testing.T) {
+	g, err := New()
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	_, err = g.AnalogizeItem(5, "flux capacitor", "")
+	if !errors.Is(err, ErrUnknownConcept) {
+		t.Errorf("error = %v, want ErrUnknownConcept", err)
+	}
+}
+
+func TestParseQuantity(t *testing.T) {
+	q, err := ParseQuantity("2.5 billion years")
+	if err != nil {
+		t.Fatalf("ParseQuantity() error: %v", err)
+	}
+	if q.Value != 2.5e9 || q.Unit != "years" {
+		t.Errorf("got %g %q, want 2.5e9 years", q.Value, q.Unit)
+	}
+}
+
+func TestAnalogizeSpeed(t *testing.T) {
+	g, err := New()
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	res, err := g.Analogize(60, "km/s")
+	if err != nil {
+		t.Fatalf("Analogize() error: %v", err)
+	}
+	if res.Dimension != "speed" || !strings.Contains(res.Sentence, "as fast as") {
+		t.Errorf("got [%s] %q, want a speed comparison", res.Dimension, res.Sentence)
+	}
+}
+
+func TestAnalogizeTime(t *testing.T) {
+	g, err := New()
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	res, err := g.AnalogizeTime(900, "km/h")
+	if err != nil {
+		t.Fatalf("AnalogizeTime() error: %v", err)
+	}
+	if res.Seconds <= 0 || res.Concept == nil || res.Ratio != 0 {
+		t.Errorf("got seconds %g, concept %v, ratio %g; want seconds and concept only", res.Seconds, res.Concept, res.Ratio)
+	}
+	if !strings.HasPrefix(res.Sentence, "900 km/h would ") {
+		t.Errorf("sentence = %q, want prefix %q", res.Sentence, "900 km/h would ")
+	}
+
+	if _, err := g.AnalogizeTime(5, "m"); err == nil {
+		t.Error("expected error for a unit that is not a speed")
+	}
+}
+
+func TestAnalogizeEnergy(t *testing.T) {
+	g, err := New()
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	res, err := g.Analogize(15, "kilotons")
+	if err != nil {
+		t.Fatalf("Analogize() error: %v", err)
+	}
+	if res.Dimension != "energy" || !strings.Contains(res.Sentence, "as much energy as") {
+		t.Errorf("got [%s] %q, want an energy comparison", res.Dimension, res.Sentence)
+	}
+
+	res, err = g.AnalogizeTime(1, "MWh")
+	if err != nil {
+		t.Fatalf("AnalogizeTime() error: %v", err)
+	}
+	if !strings.Contains(res.Sentence, " would power ") {
+		t.Errorf("sentence = %q, want %q", res.Sentence, "would power")
+	}
+}
+
+func TestAnalogizeMoney(t *testing.T) {
+	g, err := New(WithBestOnly())
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	q, err := ParseQuantity("$3.2 billion")
+	if err != nil {
+		t.Fatalf("ParseQuantity() error: %v", err)
+	}
+	res, err := g.Analogize(q.Value, q.Unit)
+	if err != nil {
+		t.Fatalf("Analogize() error: %v", err)
+	}
+	if res.Dimension != "money" || !strings.HasPrefix(res.Sentence, "$3.2 billion is ") {
+		t.Errorf("got [%s] %q, want a money comparison echoing $3.2 billion", res.Dimension, res.Sentence)
+	}
+}
+
+func TestWithPrices(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prices.json")
+	if err := os.WriteFile(path, []byte(`[{"name": "Espresso", "category": "Food", "price_usd": 3}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	g, err := New(WithPrices(path))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	res, err := g.Analogize(30, "USD")
+	if err != nil {
+		t.Fatalf("Analogize() error: %v", err)
+	}
+	want := "30 USD is enough to buy about 10 Espressos."
+	if res.Sentence != want {
+		t.Errorf("sentence = %q, want %q", res.Sentence, want)
+	}
+}
+
+func TestAnalogizeTemperature(t *testing.T) {
+	g, err := New(WithBestOnly())
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	res, err := g.Analogize(1300, "°C")
+	if err != nil {
+		t.Fatalf("Analogize() error: %v", err)
+	}
+	if res.Dimension != "temperature" || !strings.Contains(res.Sentence, " °C hotter than ") && !strings.Contains(res.Sentence, " °C colder than ") {
+		t.Errorf("got [%s] %q, want a difference in °C", res.Dimension, res.Sentence)
+	}
+	if res.Difference == 0 {
+		t.Error("Difference is unset")
+	}
+
+	if _, err := g.AnalogizeCount(5, "temperature"); err == nil {
+		t.Error("expected error counting temperatures")
+	}
+}
+
+func TestAnalogizeCountPopulation(t *testing.T) {
+	g, err := New(WithBestOnly())
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	res, err := g.AnalogizeCount(8000000, "count")
+	if err != nil {
+		t.Fatalf("AnalogizeCount() error: %v", err)
+	}
+	if res.Dimension != "count" || res.Concept == nil || res.UnitItem != nil {
+		t.Errorf("got %+v, want a single-concept count result", res)
+	}
+	if !strings.HasPrefix(res.Sentence, "8 million is ") {
+		t.Errorf("sentence = %q, want prefix %q", res.Sentence, "8 million is ")
+	}
+
+	results, err := g.AnalogizeCountTop(8000000, "count", 3)
+	if err != nil {
+		t.Fatalf("AnalogizeCountTop() error: %v", err)
+	}
+	if len(results) != 3 {
+		t.Errorf("got %d results, want 3", len(results))
+	}
+}
+
+func TestAnalogizeRate(t *testing.T) {
+	g, err := New(WithBestOnly())
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	res, err := g.Analogize(40, "tons per day")
+	if err != nil {
+		t.Fatalf("Analogize() error: %v", err)
+	}
+	if res.Dimension != "weight" || res.Window == "" || res.Seconds == 0 {
+		t.Errorf("got %+v, want a weight rate with a window", res)
+	}
+	if !strings.HasPrefix(res.Sentence, "40 tons per day adds up to ") {
+		t.Errorf("sentence = %q, want prefix %q", res.Sentence, "40 tons per day adds up to ")
+	}
+
+	results, err := g.AnalogizeTop(2.5e6, "emails per second", 3)
+	if err != nil {
+		t.Fatalf("AnalogizeTop() error: %v", err)
+	}
+	for _, r := range results {
+		if r.Dimension != "count" || r.Window == "" {
+			t.Errorf("got [%s] window %q, want a count rate", r.Dimension, r.Window)
+		}
+	}
+}
+
+func TestWithLocale(t *testing.T) {
+	us, err := New(WithBestOnly())
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	gb, err := us.With(WithLocale(EnGB))
+	if err != nil {
+		t.Fatalf("With() error: %v", err)
+	}
+
+	tests := []struct {
+		g          *Generator
+		unit       string
+		wantKg     float64
+		wantPrefix string
+	}{
+		{us, "tons", 40 * 907.18474, "40 tons is "},
+		{gb, "tons", 40 * 1016.0469088, "40 tons is "},
+		{gb, "short_ton", 40 * 907.18474, "40 short tons is "},
+		{us, "long tons", 40 * 1016.0469088, "40 long tons is "},
+	}
+	for _, tt := range tests {
+		res, err := tt.g.Analogize(40, tt.unit)
+		if err != nil {
+			t.Fatalf("Analogize(40, %q) error: %v", tt.unit, err)
+		}
+		kg, _ := res.Concept.ValueFor("weight")
+		if got := res.Ratio * kg; math.Abs(got-tt.wantKg) > tt.wantKg*1e-9 {
+			t.Errorf("Analogize(40, %q) compared %g kg, want %g", tt.unit, got, tt.wantKg)
+		}
+		if !strings.HasPrefix(res.Sentence, tt.wantPrefix) {
+			t.Errorf("Analogize(40, %q) = %q, want prefix %q", tt.unit, res.Sentence, tt.wantPrefix)
+		}
+	}
+}
+
+func TestWithUnitSystem(t *testing.T) {
+	g, err := New(WithBestOnly())
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	res, err := g.Analogize(0.003, "km")
+	if err != nil {
+		t.Fatalf("Analogize() error: %v", err)
+	}
+	if res.DisplayValue != 0.003 || res.DisplayUnit != "km" || !strings.HasPrefix(res.Sentence, "0.003 km is ") {
+		t.Errorf("got %g %q, %q, want the input as written", res.DisplayValue, res.DisplayUnit, res.Sentence)
+	}
+
+	tests := []struct {
+		system     UnitSystem
+		value      float64
+		unit       string
+		wantPrefix string
+	}{
+		{UnitsAuto, 0.003, "km", "3 m is "},
+		{UnitsImperial, 5, "km", "3.11 mi is "},
+		{UnitsMetric, 40, "tons per day", "36.3 t per day adds up to "},
+	}
+	for _, tt := range tests {
+		g, err := g.With(WithUnitSystem(tt.system))
+		if err != nil {
+			t.Fatalf("With() error: %v", err)
+		}
+		res, err := g.Analogize(tt.value, tt.unit)
+		if err != nil {
+			t.Fatalf("Analogize(%g, %q) error: %v", tt.value, tt.unit, err)
+		}
+		if !strings.HasPrefix(res.Sentence, tt.wantPrefix) {
+			t.Errorf("Analogize(%g, %q) = %q, want prefix %q", tt.value, tt.unit, res.Sentence, tt.wantPrefix)
+		}
+		if res.Value != tt.value || res.Unit != tt.unit || res.DisplayUnit == tt.unit {
+			t.Errorf("Analogize(%g, %q) reported %g %q displayed as %g %q", tt.value, tt.unit, res.Value, res.Unit, res.DisplayValue, res.DisplayUnit)
+		}
+	}
+}
+
+func TestWithConcepts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "concepts.json")
+	if err := os.WriteFile(path, []byte(`[{"name": "Our Data Center", "category": "Structure", "power_w": 3e7}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	g, err := New(WithConcepts(path), WithFilter(func(c Concept) bool { return c.Name == "Our Data Center" }))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	res, err := g.Analogize(60, "MW")
+	if err != nil {
+		t.Fatalf("Analogize() error: %v", err)
+	}
+	if res.Concept.Name != "Our Data Center" || res.Ratio != 2 {
+		t.Errorf("got %q x%g, want Our Data Center x2", res.Concept.Name, res.Ratio)
+	}
+
+	if err := os.WriteFile(path, []byte(`[{"name": "Our Data Center", "category": "Structure"}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := New(WithConcepts(path)); err == nil || !strings.Contains(err.Error(), "no measurements") {
+		t.Errorf("New() error = %v, want one about the entry without measurements", err)
+	}
+}
+
+func TestWithCitations(t *testing.T) {
+	marathon := func(c Concept) bool { return c.Name == "men's Marathon world record" }
+	g, err := New(WithFilter(marathon))
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	res, err := g.Analogize(7084*2, "s")
+	if err != nil {
+		t.Fatalf("Analogize() error: %v", err)
+	}
+	if res.Citations != nil {
+		t.Errorf("Citations = %v without WithCitations, want none", res.Citations)
+	}
+
+	g, err = g.With(WithCitations())
+	if err != nil {
+		t.Fatalf("With() error: %v", err)
+	}
+	if res, err = g.Analogize(7084*2, "s"); err != nil {
+		t.Fatalf("Analogize() error: %v", err)
+	}
+	want := []Citation{{Concept: "men's Marathon world record", Notes: "Kelvin Kiptum, 1:57:58 (2023)"}}
+	if !reflect.DeepEqual(res.Citations, want) {
+		t.Errorf("Citations = %v, want %v", res.Citations, want)
+	}
+	if got := res.Citations[0].String(); got != "men's Marathon world record: no source, note: Kelvin Kiptum, 1:57:58 (2023)" {
+		t.Errorf("Citation.String() = %q", got)
+	}
+
+	path := filepath.Join(t.TempDir(), "concepts.json")
+	if err := os.WriteFile(path, []byte(`[{"name": "Our Pallet", "category": "Object", "length_m": 1.2, "source": "ISO 6780", "as_of": "2003"}]`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	g, err = New(WithConcepts(path), WithCitations())
+	if err != nil {
+		t.Fatalf("New() error: %v", err)
+	}
+	res, err = g.AnalogizeItem(1000, "Our Pallet", "length")
+	if err != nil {
+		t.Fatalf("AnalogizeItem() error: %v", err)
+	}
+	if len(res.Citations) != 2 || res.Citations[0].String() != "Our Pallet: ISO 6780, as of 2003" || res.Citations[1].Concept != res.TargetItem.Name {
+		t.Errorf("Citations = %v, want Our Pallet and %s", res.Citations, res.TargetItem.Name)
+	}
+}
@@ -0,0 +1,162 @@
+package lnag
+
+import (
+	"math/rand/v2"
+
+	"github.com/creimer/lnag/internal/matcher"
+	"github.com/creimer/lnag/internal/units"
+)
+
+// Option configures a Generator.
+type Option func(*config)
+
+type config struct {
+	rng           *rand.Rand
+	bestOnly      bool
+	emphasis      Emphasis
+	filter        func(Concept) bool
+	unitTemplate  string
+	countTemplate string
+	pricesFile    string
+	conceptPaths  []string
+	locale        Locale
+	unitSystem    UnitSystem
+	citations     bool
+}
+
+// WithRand makes the Generator draw from r instead of the global random
+// source. r is not safe for concurrent use, so neither is the Generator.
+func WithRand(r *rand.Rand) Option {
+	return func(c *config) {
+		c.rng = r
+	}
+}
+
+// WithSeed is shorthand for WithRand seeded with seed, for reproducible output.
+func WithSeed(seed uint64) Option {
+	return WithRand(rand.New(rand.NewPCG(seed, 0)))
+}
+
+// WithBestOnly always picks the best-scoring analogy instead of a random one
+// among the top candidates. Ties are broken by concept name.
+func WithBestOnly() Option {
+	return func(c *config) {
+		c.bestOnly = true
+	}
+}
+
+// Emphasis biases analogies toward stressing how small or large a number is.
+type Emphasis = matcher.Emphasis
+
+const (
+	EmphasisNone  = matcher.EmphasisNone
+	EmphasisSmall = matcher.EmphasisSmall
+	EmphasisLarge = matcher.EmphasisLarge
+)
+
+// ParseEmphasis parses "small", "large" or "" (no emphasis).
+func ParseEmphasis(s string) (Emphasis, error) {
+	return matcher.ParseEmphasis(s)
+}
+
+// WithEmphasis prefers fractions ("half the length of...") for EmphasisSmall
+// and large multiples ("a whopping 500x...") for EmphasisLarge, and phrases
+// the sentence to match. Time analogies fail with ErrTimeEmphasis.
+func WithEmphasis(e Emphasis) Option {
+	return func(c *config) {
+		c.emphasis = e
+	}
+}
+
+// WithFilter restricts the concept library to concepts for which keep
+// returns true.
+func WithFilter(keep func(Concept) bool) Option {
+	return func(c *config) {
+		c.filter = keep
+	}
+}
+
+// WithTemplates replaces the built-in sentences with text/template sources
+// executed against the Result. The default sentence is available as
+// {{.Sentence}}. An empty string keeps the built-in sentence for that mode.
+func WithTemplates(unit, count string) Option {
+	return func(c *config) {
+		c.unitTemplate = unit
+		c.countTemplate = count
+	}
+}
+
+// WithConcepts adds the concepts in JSON files, in the schema of the
+// built-in library, to the library. A path may be a directory of .json
+// files. A concept named like a built-in one, or one from an earlier path,
+// replaces it. Like WithPrices it only takes effect in New.
+func WithConcepts(paths ...string) Option {
+	return func(c *config) {
+		c.conceptPaths = append(c.conceptPaths, paths...)
+	}
+}
+
+// WithPrices replaces the embedded concept prices with the JSON file at
+// path, a list of {"name", "category", "price_usd"} entries. Entries named
+// like an existing concept add a price to it. It only takes effect in New;
+// With does not reload the concept library.
+func WithPrices(path string) Option {
+	return func(c *config) {
+		c.pricesFile = path
+	}
+}
+
+// Locale decides what ambiguous unit names such as "ton" and "gallon" mean.
+type Locale = units.Locale
+
+const (
+	EnUS = units.EnUS // short tons and US gallons (the default)
+	EnGB = units.EnGB // long tons and imperial gallons
+)
+
+// ParseLocale parses "en-US", "en-GB" or "" (the default, en-US).
+func ParseLocale(s string) (Locale, error) {
+	return units.ParseLocale(s)
+}
+
+// WithLocale reads ambiguous unit names the way readers in l use them, both
+// in the input and in the sentence's echo of it: with EnGB, "40 tons" is 40
+// long tons and "3 US gallons" is echoed as such.
+func WithLocale(l Locale) Option {
+	return func(c *config) {
+		c.locale = l
+	}
+}
+
+// UnitSystem is the family of units an input is restated in for display.
+type UnitSystem = units.System
+
+const (
+	UnitsAsWritten = units.AsWritten // echo the input as given (the default)
+	UnitsAuto      = units.Auto      // "0.003 km" is echoed as "3 m"
+	UnitsMetric    = units.Metric
+	UnitsImperial  = units.Imperial
+)
+
+// ParseUnitSystem parses "auto", "metric", "imperial" or "" (as written).
+func ParseUnitSystem(s string) (UnitSystem, error) {
+	return units.ParseSystem(s)
+}
+
+// WithUnitSystem restates the input in s when echoing it: UnitsAuto picks a
+// readable unit in the input's own system and UnitsMetric or UnitsImperial
+// convert to that system. Results report the restated input in
+// DisplayValue and DisplayUnit.
+func WithUnitSystem(s UnitSystem) Option {
+	return func(c *config) {
+		c.unitSystem = s
+	}
+}
+
+// WithCitations sets Result.Citations to the source, date and notes of
+// each concept a sentence names, for checking it before publishing.
+func WithCitations() Option {
+	return func(c *config) {
+		c.citations = true
+	}
+}